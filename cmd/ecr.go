@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jaehong21/hibiscus/config"
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+)
+
+var dockerConfigPath string
+
+func init() {
+	rootCmd.AddCommand(ecrCmd)
+	ecrCmd.AddCommand(ecrLoginCmd)
+
+	ecrLoginCmd.Flags().StringVar(&dockerConfigPath, "docker-config", ecr.DockerConfigPath(), "docker config.json to write credentials to")
+}
+
+var ecrCmd = &cobra.Command{
+	Use:   "ecr",
+	Short: "Amazon ECR helpers",
+}
+
+var ecrLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Write ECR registry credentials to the docker config",
+	Long: `Fetch an ECR authorization token for the selected AWS profile and store it
+in the docker config file, equivalent to
+'aws ecr get-login-password | docker login --username AWS --password-stdin <registry>'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.Initialize()
+		config.SetAwsProfile(awsProfile)
+
+		auth, err := ecr.GetAuthorizationToken()
+		if err != nil {
+			log.Fatal(fmt.Errorf("get authorization token: %w", err))
+		}
+
+		warning, err := ecr.WriteDockerConfig(auth, dockerConfigPath)
+		if err != nil {
+			log.Fatal(fmt.Errorf("write docker config: %w", err))
+		}
+
+		fmt.Printf("Login credentials for %s written to %s\n", auth.Registry(), dockerConfigPath)
+		if auth.ExpiresAt != nil {
+			fmt.Printf("Expires at: %s\n", auth.ExpiresAt.Local().Format(time.RFC3339))
+		}
+		if warning != "" {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	},
+}
@@ -7,9 +7,14 @@ import (
 
 	"github.com/jaehong21/hibiscus/config"
 	app "github.com/jaehong21/hibiscus/tviewapp/hibiscus"
+	alarmssvc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/alarms"
+	ec2svc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/ec2"
 	ecrsvc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/ecr"
+	ecrpublicsvc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/ecrpublic"
 	elbsvc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/elb"
+	logssvc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/logs"
 	route53svc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/route53"
+	s3svc "github.com/jaehong21/hibiscus/tviewapp/hibiscus/services/s3"
 	"github.com/spf13/cobra"
 )
 
@@ -28,23 +33,42 @@ var awsProfile string
 func init() {
 	rootCmd.AddCommand(versionCmd)
 
-	rootCmd.Flags().StringVarP(&awsProfile, "profile", "p", config.DefaultAwsProfile(), "AWS profile to use")
+	rootCmd.PersistentFlags().StringVarP(&awsProfile, "profile", "p", config.DefaultAwsProfile(), "AWS profile to use")
 }
 
 var rootCmd = &cobra.Command{
-	Use:   "hibiscus",
+	Use:   "hibiscus [service://resource]",
 	Short: "Hibiscus is a modern terminal UI for AWS console",
 	Long: `Hibiscus is a modern terminal UI for AWS console. 
             It is built with tview and cobra.
-            It aims to provide a simple and intuitive way to interact with AWS services.`,
+            It aims to provide a simple and intuitive way to interact with AWS services.
+
+            An optional deep link opens a resource directly, e.g.
+            hibiscus route53://Z123/api.example.com
+            hibiscus elb://my-load-balancer`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		var start *app.Location
+		if len(args) == 1 {
+			loc, err := app.ParseURI(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			start = &loc
+		}
+
 		newConfig := config.Initialize()
 		config.SetAwsProfile(awsProfile)
 
 		factories := []app.ServiceFactory{
 			func(ctx app.ServiceContext) app.Service { return ecrsvc.New(ctx) },
+			func(ctx app.ServiceContext) app.Service { return ecrpublicsvc.New(ctx) },
 			func(ctx app.ServiceContext) app.Service { return route53svc.New(ctx) },
 			func(ctx app.ServiceContext) app.Service { return elbsvc.New(ctx) },
+			func(ctx app.ServiceContext) app.Service { return s3svc.New(ctx) },
+			func(ctx app.ServiceContext) app.Service { return ec2svc.New(ctx) },
+			func(ctx app.ServiceContext) app.Service { return logssvc.New(ctx) },
+			func(ctx app.ServiceContext) app.Service { return alarmssvc.New(ctx) },
 		}
 
 		app, err := app.New(newConfig, factories)
@@ -52,6 +76,12 @@ var rootCmd = &cobra.Command{
 			log.Fatal(err)
 		}
 
+		if start != nil {
+			if err := app.StartAt(*start); err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		if err := app.Run(); err != nil {
 			log.Fatal(err)
 		}
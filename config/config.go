@@ -10,8 +10,11 @@ import (
 )
 
 type Config struct {
-	AwsProfile string
-	TabKey     int
+	AwsProfile    string
+	TabKey        int
+	EcrPricePerGB float64
+	SshCommand    string
+	AwsCli        string
 }
 
 // DefaultAwsProfile returns the AWS profile hibiscus should use when none is provided via CLI flag
@@ -33,6 +36,11 @@ type HibiscusConfig struct {
 // PersistentConfig only stores values we want to persist between sessions
 type PersistentConfig struct {
 	ServiceName string `yaml:"service_name"`
+	// EcrPricePerGB is a pointer so that a saved price of 0 is told apart
+	// from an unset one.
+	EcrPricePerGB *float64 `yaml:"ecr_price_per_gb,omitempty"`
+	SshCommand    string   `yaml:"ssh_command,omitempty"`
+	AwsCli        string   `yaml:"aws_cli,omitempty"`
 }
 
 var (
@@ -61,6 +69,16 @@ func tabKeyToServiceName(tabKey int) string {
 		return "route53"
 	case ELB_TAB:
 		return "elb"
+	case ECR_PUBLIC_TAB:
+		return "ecr-public"
+	case S3_TAB:
+		return "s3"
+	case EC2_TAB:
+		return "ec2"
+	case LOGS_TAB:
+		return "logs"
+	case ALARMS_TAB:
+		return "alarms"
 	default:
 		return "ecr" // Default to ECR if unknown
 	}
@@ -75,6 +93,16 @@ func serviceNameToTabKey(serviceName string) int {
 		return ROUTE53_TAB
 	case "elb":
 		return ELB_TAB
+	case "ecr-public":
+		return ECR_PUBLIC_TAB
+	case "s3":
+		return S3_TAB
+	case "ec2":
+		return EC2_TAB
+	case "logs":
+		return LOGS_TAB
+	case "alarms":
+		return ALARMS_TAB
 	default:
 		return ECR_TAB // Default to ECR if unknown
 	}
@@ -86,8 +114,24 @@ func Initialize() *Config {
 
 	// Create default config
 	globalConfig = &Config{
-		AwsProfile: DefaultAwsProfile(),
-		TabKey:     ECR_TAB, // Default tab
+		AwsProfile:    DefaultAwsProfile(),
+		TabKey:        ECR_TAB, // Default tab
+		EcrPricePerGB: DEFAULT_ECR_PRICE_PER_GB,
+		SshCommand:    DEFAULT_SSH_COMMAND,
+		AwsCli:        DEFAULT_AWS_CLI,
+	}
+
+	// Settings other than the tab are always restored from file
+	if hibiscusConfig, err := loadConfigFromFile(); err == nil && hibiscusConfig != nil {
+		if price := hibiscusConfig.Hibiscus.EcrPricePerGB; price != nil && *price >= 0 {
+			globalConfig.EcrPricePerGB = *price
+		}
+		if hibiscusConfig.Hibiscus.SshCommand != "" {
+			globalConfig.SshCommand = hibiscusConfig.Hibiscus.SshCommand
+		}
+		if hibiscusConfig.Hibiscus.AwsCli != "" {
+			globalConfig.AwsCli = hibiscusConfig.Hibiscus.AwsCli
+		}
 	}
 
 	// TODO: Try to load saved tab from file
@@ -119,6 +163,16 @@ func SetAwsProfile(profile string) {
 	// Don't save the profile - it's not persisted
 }
 
+// SetEcrPricePerGB sets the storage price used by the ECR cost report
+func SetEcrPricePerGB(price float64) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	globalConfig.EcrPricePerGB = price
+
+	saveConfigToFile()
+}
+
 func SetTabKey(key int) {
 	configMutex.Lock()
 	defer configMutex.Unlock()
@@ -130,7 +184,6 @@ func SetTabKey(key int) {
 }
 
 // Load configuration from file
-// WARN: dead_code
 func loadConfigFromFile() (*HibiscusConfig, error) {
 	// Ensure config directory exists
 	if err := os.MkdirAll(configDir, 0o755); err != nil {
@@ -156,6 +209,17 @@ func saveConfigToFile() error {
 	persistentConfig := PersistentConfig{
 		ServiceName: tabKeyToServiceName(globalConfig.TabKey),
 	}
+	// Only customised settings are written back, so defaults can change.
+	if globalConfig.EcrPricePerGB != DEFAULT_ECR_PRICE_PER_GB {
+		price := globalConfig.EcrPricePerGB
+		persistentConfig.EcrPricePerGB = &price
+	}
+	if globalConfig.SshCommand != DEFAULT_SSH_COMMAND {
+		persistentConfig.SshCommand = globalConfig.SshCommand
+	}
+	if globalConfig.AwsCli != DEFAULT_AWS_CLI {
+		persistentConfig.AwsCli = globalConfig.AwsCli
+	}
 
 	// Wrap it in the top-level HibiscusConfig
 	hibiscusConfig := HibiscusConfig{
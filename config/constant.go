@@ -5,8 +5,25 @@ const (
 	ECR_TAB
 	ROUTE53_TAB
 	ELB_TAB
+	ECR_PUBLIC_TAB
+	S3_TAB
+	EC2_TAB
+	LOGS_TAB
+	ALARMS_TAB
 )
 
 const (
 	AWS_PROFILE = ""
 )
+
+// DEFAULT_ECR_PRICE_PER_GB is the ECR storage price (USD per GB-month) used
+// until the user configures their own.
+// https://aws.amazon.com/ecr/pricing/
+const DEFAULT_ECR_PRICE_PER_GB = 0.10
+
+// DEFAULT_SSH_COMMAND is the command template used to SSH into an EC2
+// instance; see shell.SSHCommand for the placeholders.
+const DEFAULT_SSH_COMMAND = "ssh {{ip}}"
+
+// DEFAULT_AWS_CLI is the AWS CLI executable used to start SSM sessions.
+const DEFAULT_AWS_CLI = "aws"
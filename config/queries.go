@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SavedQuery is a CloudWatch Logs Insights query kept between sessions.
+type SavedQuery struct {
+	Name      string   `yaml:"name"`
+	Query     string   `yaml:"query"`
+	LogGroups []string `yaml:"log_groups,omitempty"`
+	// Range is the key of a relative time range such as "1h".
+	Range string `yaml:"range,omitempty"`
+}
+
+type savedQueries struct {
+	Queries []SavedQuery `yaml:"queries"`
+}
+
+// Saved queries live next to config.yaml so the main config stays small.
+var queriesFile = filepath.Join(configDir, "queries.yaml")
+
+// LoadSavedQueries returns the saved Logs Insights queries sorted by name.
+func LoadSavedQueries() ([]SavedQuery, error) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+
+	return loadSavedQueries()
+}
+
+// SaveQuery stores query, replacing a saved query with the same name.
+func SaveQuery(query SavedQuery) error {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	queries, err := loadSavedQueries()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for idx := range queries {
+		if queries[idx].Name == query.Name {
+			queries[idx] = query
+			replaced = true
+		}
+	}
+	if !replaced {
+		queries = append(queries, query)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].Name < queries[j].Name
+	})
+
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(savedQueries{Queries: queries}); err != nil {
+		return err
+	}
+
+	return os.WriteFile(queriesFile, buf.Bytes(), 0o644)
+}
+
+func loadSavedQueries() ([]SavedQuery, error) {
+	data, err := os.ReadFile(queriesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saved savedQueries
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	sort.Slice(saved.Queries, func(i, j int) bool {
+		return saved.Queries[i].Name < saved.Queries[j].Name
+	})
+	return saved.Queries, nil
+}
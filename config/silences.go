@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AlarmSilence records a CloudWatch alarm whose actions were disabled until
+// Until; they are enabled again once it has passed. The ARN pins the alarm
+// to its account and region, since profiles can change between sessions.
+type AlarmSilence struct {
+	Alarm string    `yaml:"alarm"`
+	Arn   string    `yaml:"arn"`
+	Until time.Time `yaml:"until"`
+}
+
+type alarmSilences struct {
+	Silences []AlarmSilence `yaml:"silences"`
+}
+
+// Silences live next to config.yaml so they survive restarts.
+var silencesFile = filepath.Join(configDir, "silences.yaml")
+
+// LoadAlarmSilences returns the recorded silences sorted by alarm ARN.
+func LoadAlarmSilences() ([]AlarmSilence, error) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+
+	return loadAlarmSilences()
+}
+
+// SaveAlarmSilences records silences, replacing existing ones for the same
+// alarm ARNs.
+func SaveAlarmSilences(silences []AlarmSilence) error {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	existing, err := loadAlarmSilences()
+	if err != nil {
+		return err
+	}
+	replaced := map[string]bool{}
+	for _, silence := range silences {
+		replaced[silence.Arn] = true
+	}
+	for _, silence := range existing {
+		if !replaced[silence.Arn] {
+			silences = append(silences, silence)
+		}
+	}
+	return writeAlarmSilences(silences)
+}
+
+// RemoveAlarmSilences forgets the silences of the alarms with the given ARNs.
+func RemoveAlarmSilences(arns []string) error {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	existing, err := loadAlarmSilences()
+	if err != nil {
+		return err
+	}
+	removed := map[string]bool{}
+	for _, arn := range arns {
+		removed[arn] = true
+	}
+	kept := existing[:0]
+	for _, silence := range existing {
+		if !removed[silence.Arn] {
+			kept = append(kept, silence)
+		}
+	}
+	if len(kept) == len(existing) {
+		return nil
+	}
+	return writeAlarmSilences(kept)
+}
+
+func loadAlarmSilences() ([]AlarmSilence, error) {
+	data, err := os.ReadFile(silencesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saved alarmSilences
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	return saved.Silences, nil
+}
+
+func writeAlarmSilences(silences []AlarmSilence) error {
+	sort.Slice(silences, func(i, j int) bool {
+		return silences[i].Arn < silences[j].Arn
+	})
+
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(alarmSilences{Silences: silences}); err != nil {
+		return err
+	}
+
+	return os.WriteFile(silencesFile, buf.Bytes(), 0o644)
+}
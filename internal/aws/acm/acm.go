@@ -0,0 +1,38 @@
+package acm
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/jaehong21/hibiscus/internal/aws"
+)
+
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/acm
+var client *acm.Client
+
+func DescribeCertificate(certificateArn *string) (*types.CertificateDetail, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	output, err := client.DescribeCertificate(context.TODO(), &acm.DescribeCertificateInput{
+		CertificateArn: certificateArn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Certificate, nil
+}
+
+func setupClient() error {
+	if client != nil {
+		return nil
+	}
+	cfg, err := aws.GetAWSConfig(context.Background())
+	if err != nil {
+		return err
+	}
+	client = acm.NewFromConfig(cfg)
+	return nil
+}
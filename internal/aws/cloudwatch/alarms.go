@@ -0,0 +1,145 @@
+package cloudwatch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// HistoryLimit caps the alarm history items fetched for one alarm.
+const HistoryLimit = 100
+
+// maxAlarmNames is the most alarm names one DescribeAlarms call accepts.
+const maxAlarmNames = 100
+
+var alarmTypes = []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm}
+
+// DescribeAlarms returns every metric and composite alarm in the region,
+// following NextToken across pages. A non-empty state only returns alarms in
+// that state.
+func DescribeAlarms(state types.StateValue) ([]types.MetricAlarm, []types.CompositeAlarm, error) {
+	if err := setupClient(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		metricAlarms    []types.MetricAlarm
+		compositeAlarms []types.CompositeAlarm
+		token           *string
+	)
+	for {
+		resp, err := client.DescribeAlarms(context.TODO(), &cloudwatch.DescribeAlarmsInput{
+			AlarmTypes: alarmTypes,
+			StateValue: state,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		metricAlarms = append(metricAlarms, resp.MetricAlarms...)
+		compositeAlarms = append(compositeAlarms, resp.CompositeAlarms...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return metricAlarms, compositeAlarms, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+// AlarmArns returns the ARNs of the named alarms that exist in the account
+// and region of the current client.
+func AlarmArns(alarmNames []string) (map[string]bool, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	arns := map[string]bool{}
+	for offset := 0; offset < len(alarmNames); offset += maxAlarmNames {
+		input := &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: alarmNames[offset:min(offset+maxAlarmNames, len(alarmNames))],
+			AlarmTypes: alarmTypes,
+		}
+		for {
+			resp, err := client.DescribeAlarms(context.TODO(), input)
+			if err != nil {
+				return nil, err
+			}
+			for _, alarm := range resp.MetricAlarms {
+				arns[valueOr(alarm.AlarmArn)] = true
+			}
+			for _, alarm := range resp.CompositeAlarms {
+				arns[valueOr(alarm.AlarmArn)] = true
+			}
+			if resp.NextToken == nil || *resp.NextToken == "" {
+				break
+			}
+			input.NextToken = resp.NextToken
+		}
+	}
+	return arns, nil
+}
+
+// AlarmName returns the alarm name of an ARN such as
+// "arn:aws:cloudwatch:us-east-1:123456789012:alarm:my-alarm". Alarm names
+// may contain colons, so everything after the resource type is kept.
+func AlarmName(arn string) string {
+	parts := strings.SplitN(arn, ":", 7)
+	if len(parts) < 7 || parts[5] != "alarm" {
+		return ""
+	}
+	return parts[6]
+}
+
+// DescribeAlarmHistory returns up to HistoryLimit history items of an alarm,
+// newest first.
+func DescribeAlarmHistory(alarmName *string) ([]types.AlarmHistoryItem, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		items []types.AlarmHistoryItem
+		token *string
+	)
+	for len(items) < HistoryLimit {
+		maxRecords := int32(HistoryLimit - len(items))
+		resp, err := client.DescribeAlarmHistory(context.TODO(), &cloudwatch.DescribeAlarmHistoryInput{
+			AlarmName:  alarmName,
+			AlarmTypes: alarmTypes,
+			ScanBy:     types.ScanByTimestampDescending,
+			MaxRecords: &maxRecords,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp.AlarmHistoryItems...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		token = resp.NextToken
+	}
+
+	return items, nil
+}
+
+func EnableAlarmActions(alarmNames []string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.EnableAlarmActions(context.TODO(), &cloudwatch.EnableAlarmActionsInput{
+		AlarmNames: alarmNames,
+	})
+	return err
+}
+
+func DisableAlarmActions(alarmNames []string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.DisableAlarmActions(context.TODO(), &cloudwatch.DisableAlarmActionsInput{
+		AlarmNames: alarmNames,
+	})
+	return err
+}
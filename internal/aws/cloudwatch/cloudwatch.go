@@ -0,0 +1,148 @@
+package cloudwatch
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/jaehong21/hibiscus/internal/aws"
+)
+
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/cloudwatch
+var client *cloudwatch.Client
+
+// maxQueriesPerCall is the most metric queries one GetMetricData call
+// accepts.
+const maxQueriesPerCall = 500
+
+type Dimension struct {
+	Name  string
+	Value string
+}
+
+// MetricQuery is one metric series to fetch. ID must be unique within a
+// GetMetricData call, start with a lowercase letter and only contain
+// letters, digits and underscores.
+type MetricQuery struct {
+	ID         string
+	Namespace  string
+	MetricName string
+	Dimensions []Dimension
+	// Stat is a statistic such as "Sum" or "Average", or a percentile such
+	// as "p99".
+	Stat string
+}
+
+// Series holds one value per period, oldest first. Periods without data are
+// NaN.
+type Series []float64
+
+// GetMetricData fetches every query over [start, end) at period resolution,
+// batching as many queries into each call as the API allows. start is
+// aligned down to a multiple of period, and the returned series are keyed
+// by query ID.
+func GetMetricData(queries []MetricQuery, start, end time.Time, period time.Duration) (map[string]Series, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	start = start.Truncate(period)
+	points := int(math.Ceil(float64(end.Sub(start)) / float64(period)))
+	seconds := int32(period.Seconds())
+
+	series := make(map[string]Series, len(queries))
+	for _, query := range queries {
+		values := make(Series, points)
+		for idx := range values {
+			values[idx] = math.NaN()
+		}
+		series[query.ID] = values
+	}
+
+	for offset := 0; offset < len(queries); offset += maxQueriesPerCall {
+		batch := queries[offset:min(offset+maxQueriesPerCall, len(queries))]
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime:         &start,
+			EndTime:           &end,
+			ScanBy:            types.ScanByTimestampAscending,
+			MetricDataQueries: make([]types.MetricDataQuery, 0, len(batch)),
+		}
+		for _, query := range batch {
+			input.MetricDataQueries = append(input.MetricDataQueries, metricDataQuery(query, seconds))
+		}
+
+		for {
+			resp, err := client.GetMetricData(context.TODO(), input)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range resp.MetricDataResults {
+				values, ok := series[valueOr(result.Id)]
+				if !ok {
+					continue
+				}
+				for idx, timestamp := range result.Timestamps {
+					if idx >= len(result.Values) {
+						break
+					}
+					point := int(timestamp.Sub(start) / period)
+					if point >= 0 && point < len(values) {
+						values[point] = result.Values[idx]
+					}
+				}
+			}
+			if resp.NextToken == nil || *resp.NextToken == "" {
+				break
+			}
+			input.NextToken = resp.NextToken
+		}
+	}
+
+	return series, nil
+}
+
+func metricDataQuery(query MetricQuery, period int32) types.MetricDataQuery {
+	id, namespace, metricName, stat := query.ID, query.Namespace, query.MetricName, query.Stat
+	dimensions := make([]types.Dimension, 0, len(query.Dimensions))
+	for _, dimension := range query.Dimensions {
+		name, value := dimension.Name, dimension.Value
+		dimensions = append(dimensions, types.Dimension{Name: &name, Value: &value})
+	}
+	returnData := true
+	return types.MetricDataQuery{
+		Id: &id,
+		MetricStat: &types.MetricStat{
+			Metric: &types.Metric{
+				Namespace:  &namespace,
+				MetricName: &metricName,
+				Dimensions: dimensions,
+			},
+			Period: &period,
+			Stat:   &stat,
+		},
+		ReturnData: &returnData,
+	}
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func setupClient() error {
+	if client != nil {
+		return nil
+	}
+
+	cfg, err := aws.GetAWSConfig(context.Background())
+	if err != nil {
+		return err
+	}
+
+	client = cloudwatch.NewFromConfig(cfg)
+	return nil
+}
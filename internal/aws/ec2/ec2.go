@@ -0,0 +1,149 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/jaehong21/hibiscus/internal/aws"
+)
+
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/ec2
+var client *ec2.Client
+
+// DescribeInstances returns every instance in the region, following
+// NextToken across pages.
+func DescribeInstances() ([]types.Instance, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		instances []types.Instance
+		token     *string
+	)
+	for {
+		resp, err := client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range resp.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return instances, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+func DescribeSecurityGroups(groupIDs []string) ([]types.SecurityGroup, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	groups, err := client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{
+		GroupIds: groupIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups.SecurityGroups, nil
+}
+
+func DescribeVolumes(volumeIDs []string) ([]types.Volume, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	if len(volumeIDs) == 0 {
+		return nil, nil
+	}
+	volumes, err := client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{
+		VolumeIds: volumeIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes.Volumes, nil
+}
+
+func StartInstance(instanceID *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.StartInstances(context.TODO(), &ec2.StartInstancesInput{
+		InstanceIds: []string{*instanceID},
+	})
+	return err
+}
+
+func StopInstance(instanceID *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.StopInstances(context.TODO(), &ec2.StopInstancesInput{
+		InstanceIds: []string{*instanceID},
+	})
+	return err
+}
+
+func RebootInstance(instanceID *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.RebootInstances(context.TODO(), &ec2.RebootInstancesInput{
+		InstanceIds: []string{*instanceID},
+	})
+	return err
+}
+
+func TerminateInstance(instanceID *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.TerminateInstances(context.TODO(), &ec2.TerminateInstancesInput{
+		InstanceIds: []string{*instanceID},
+	})
+	return err
+}
+
+// Region returns the region the client talks to.
+func Region() (string, error) {
+	if err := setupClient(); err != nil {
+		return "", err
+	}
+	return client.Options().Region, nil
+}
+
+// InstanceName returns the value of the instance's Name tag.
+func InstanceName(instance types.Instance) string {
+	return TagValue(instance.Tags, "Name")
+}
+
+// TagValue returns the value of tag key, or "" when it is not set.
+func TagValue(tags []types.Tag, key string) string {
+	for _, tag := range tags {
+		if tag.Key != nil && *tag.Key == key && tag.Value != nil {
+			return *tag.Value
+		}
+	}
+	return ""
+}
+
+func setupClient() error {
+	if client != nil {
+		return nil
+	}
+	cfg, err := aws.GetAWSConfig(context.Background())
+	if err != nil {
+		return err
+	}
+	client = ec2.NewFromConfig(cfg)
+	return nil
+}
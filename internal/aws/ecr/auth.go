@@ -0,0 +1,179 @@
+package ecr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+)
+
+// AuthorizationData is a decoded ECR authorization token ready to be handed
+// to docker.
+type AuthorizationData struct {
+	Username      string
+	Password      string
+	ProxyEndpoint string
+	ExpiresAt     *time.Time
+}
+
+// Registry returns the registry host without the URL scheme, as docker
+// expects it in config.json and on the command line.
+func (a *AuthorizationData) Registry() string {
+	host := strings.TrimPrefix(a.ProxyEndpoint, "https://")
+	return strings.TrimPrefix(host, "http://")
+}
+
+// Region returns the region of a private registry host such as
+// "123456789012.dkr.ecr.us-east-1.amazonaws.com".
+func (a *AuthorizationData) Region() string {
+	parts := strings.Split(a.Registry(), ".")
+	for idx := 0; idx+1 < len(parts); idx++ {
+		if parts[idx] == "ecr" {
+			return parts[idx+1]
+		}
+	}
+	return ""
+}
+
+// encoded returns the base64 "user:password" pair used by config.json.
+func (a *AuthorizationData) encoded() string {
+	return base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.Password))
+}
+
+func GetAuthorizationToken() (*AuthorizationData, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAuthorizationToken(context.TODO(), &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.AuthorizationData) == 0 || resp.AuthorizationData[0].AuthorizationToken == nil {
+		return nil, fmt.Errorf("no authorization data returned")
+	}
+
+	data := resp.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(*data.AuthorizationToken)
+	if err != nil {
+		return nil, fmt.Errorf("decode authorization token: %w", err)
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed authorization token")
+	}
+
+	return &AuthorizationData{
+		Username:      username,
+		Password:      password,
+		ProxyEndpoint: valueOr(data.ProxyEndpoint),
+		ExpiresAt:     data.ExpiresAt,
+	}, nil
+}
+
+// DockerLoginCommand renders a shell command that logs docker into the
+// registry. The password is fetched by the AWS CLI when the command runs, so
+// it never ends up on the clipboard, in shell history or in ps output.
+func DockerLoginCommand(auth *AuthorizationData, profile string) string {
+	login := "aws ecr get-login-password"
+	if region := auth.Region(); region != "" {
+		login += " --region " + region
+	}
+	if profile != "" {
+		login += " --profile " + profile
+	}
+	return fmt.Sprintf("%s | docker login --username %s --password-stdin %s", login, auth.Username, auth.Registry())
+}
+
+// DockerConfigAuthEntry renders the config.json snippet docker stores after a
+// successful login.
+func DockerConfigAuthEntry(auth *AuthorizationData) (string, error) {
+	entry := map[string]any{
+		"auths": map[string]any{
+			auth.Registry(): map[string]string{"auth": auth.encoded()},
+		},
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DockerConfigPath returns the config.json docker reads, honoring
+// DOCKER_CONFIG like the docker CLI does.
+func DockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".docker", "config.json")
+}
+
+// WriteDockerConfig merges the registry credentials into the docker config
+// file at path, preserving every other setting. The returned warning is
+// non-empty when docker will ignore the entry because a credential store or
+// helper takes precedence.
+func WriteDockerConfig(auth *AuthorizationData, path string) (string, error) {
+	cfg := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if len(strings.TrimSpace(string(data))) > 0 {
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return "", fmt.Errorf("parse %s: %w", path, err)
+			}
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return "", err
+	}
+
+	auths := map[string]json.RawMessage{}
+	if raw, ok := cfg["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return "", fmt.Errorf("parse auths in %s: %w", path, err)
+		}
+	}
+	entry, err := json.Marshal(map[string]string{"auth": auth.encoded()})
+	if err != nil {
+		return "", err
+	}
+	auths[auth.Registry()] = entry
+	if cfg["auths"], err = json.Marshal(auths); err != nil {
+		return "", err
+	}
+
+	out, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, append(out, '\n'), 0o600); err != nil {
+		return "", err
+	}
+
+	return credentialStoreWarning(cfg, auth.Registry()), nil
+}
+
+func credentialStoreWarning(cfg map[string]json.RawMessage, registry string) string {
+	var helpers map[string]string
+	if raw, ok := cfg["credHelpers"]; ok && json.Unmarshal(raw, &helpers) == nil {
+		if helper, ok := helpers[registry]; ok {
+			return fmt.Sprintf("credHelpers maps %s to %q, which docker uses instead of this entry", registry, helper)
+		}
+	}
+	var store string
+	if raw, ok := cfg["credsStore"]; ok && json.Unmarshal(raw, &store) == nil && store != "" {
+		return fmt.Sprintf("credsStore %q is configured; docker may ignore plain auths entries", store)
+	}
+	return ""
+}
@@ -36,6 +36,22 @@ func DescribeRepositories() ([]types.Repository, error) {
 }
 
 func DescribeImages(repositoryName *string) ([]types.ImageDetail, error) {
+	result, err := listImages(repositoryName, &types.DescribeImagesFilter{
+		TagStatus: types.TagStatusTagged,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// sort by ImagePushedAt
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ImagePushedAt.After(*result[j].ImagePushedAt)
+	})
+
+	return result, nil
+}
+
+func listImages(repositoryName *string, filter *types.DescribeImagesFilter) ([]types.ImageDetail, error) {
 	if err := setupClient(); err != nil {
 		return nil, err
 	}
@@ -48,10 +64,8 @@ func DescribeImages(repositoryName *string) ([]types.ImageDetail, error) {
 	for {
 		input := &ecr.DescribeImagesInput{
 			RepositoryName: repositoryName,
-			Filter: &types.DescribeImagesFilter{
-				TagStatus: types.TagStatusTagged,
-			},
-			MaxResults: &max,
+			Filter:         filter,
+			MaxResults:     &max,
 		}
 		if nextToken != nil {
 			input.NextToken = nextToken
@@ -70,11 +84,6 @@ func DescribeImages(repositoryName *string) ([]types.ImageDetail, error) {
 		nextToken = resp.NextToken
 	}
 
-	// sort by ImagePushedAt
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].ImagePushedAt.After(*result[j].ImagePushedAt)
-	})
-
 	return result, nil
 }
 
@@ -0,0 +1,164 @@
+package ecr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+const (
+	lifecyclePreviewPollInterval = 2 * time.Second
+	lifecyclePreviewTimeout      = 2 * time.Minute
+)
+
+// GetLifecyclePolicy returns the lifecycle policy JSON for a repository. An
+// empty string is returned when the repository has no policy yet.
+func GetLifecyclePolicy(repositoryName *string) (string, error) {
+	if err := setupClient(); err != nil {
+		return "", err
+	}
+
+	resp, err := client.GetLifecyclePolicy(context.TODO(), &ecr.GetLifecyclePolicyInput{
+		RepositoryName: repositoryName,
+	})
+	if err != nil {
+		var notFound *types.LifecyclePolicyNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if resp.LifecyclePolicyText == nil {
+		return "", nil
+	}
+	return *resp.LifecyclePolicyText, nil
+}
+
+func PutLifecyclePolicy(repositoryName *string, policy string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.PutLifecyclePolicy(context.TODO(), &ecr.PutLifecyclePolicyInput{
+		RepositoryName:      repositoryName,
+		LifecyclePolicyText: &policy,
+	})
+	return err
+}
+
+// PreviewLifecyclePolicy starts a lifecycle policy preview for the given
+// policy text and polls until ECR reports a final status, returning every
+// image the policy would expire.
+func PreviewLifecyclePolicy(repositoryName *string, policy string) ([]types.LifecyclePolicyPreviewResult, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	_, err := client.StartLifecyclePolicyPreview(context.TODO(), &ecr.StartLifecyclePolicyPreviewInput{
+		RepositoryName:      repositoryName,
+		LifecyclePolicyText: &policy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(lifecyclePreviewTimeout)
+	for {
+		results, status, err := getLifecyclePolicyPreview(repositoryName)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status {
+		case types.LifecyclePolicyPreviewStatusComplete:
+			return results, nil
+		case types.LifecyclePolicyPreviewStatusFailed, types.LifecyclePolicyPreviewStatusExpired:
+			return nil, fmt.Errorf("lifecycle policy preview %s", status)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lifecycle policy preview did not complete within %s", lifecyclePreviewTimeout)
+		}
+		time.Sleep(lifecyclePreviewPollInterval)
+	}
+}
+
+func getLifecyclePolicyPreview(repositoryName *string) ([]types.LifecyclePolicyPreviewResult, types.LifecyclePolicyPreviewStatus, error) {
+	var (
+		result    []types.LifecyclePolicyPreviewResult
+		nextToken *string
+		max       = int32(100)
+	)
+
+	for {
+		input := &ecr.GetLifecyclePolicyPreviewInput{
+			RepositoryName: repositoryName,
+			MaxResults:     &max,
+		}
+		if nextToken != nil {
+			input.NextToken = nextToken
+		}
+
+		resp, err := client.GetLifecyclePolicyPreview(context.TODO(), input)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if resp.Status != types.LifecyclePolicyPreviewStatusComplete {
+			return nil, resp.Status, nil
+		}
+
+		result = append(result, resp.PreviewResults...)
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			return result, resp.Status, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// ValidateLifecyclePolicy performs a local sanity check of a lifecycle policy
+// document before it is sent to ECR, so obvious mistakes surface instantly.
+func ValidateLifecyclePolicy(policy string) error {
+	var doc struct {
+		Rules []struct {
+			RulePriority *int            `json:"rulePriority"`
+			Selection    json.RawMessage `json:"selection"`
+			Action       *struct {
+				Type string `json:"type"`
+			} `json:"action"`
+		} `json:"rules"`
+	}
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if len(doc.Rules) == 0 {
+		return fmt.Errorf("policy must contain at least one rule")
+	}
+
+	seen := map[int]bool{}
+	for idx, rule := range doc.Rules {
+		if rule.RulePriority == nil {
+			return fmt.Errorf("rule %d: rulePriority is required", idx+1)
+		}
+		if seen[*rule.RulePriority] {
+			return fmt.Errorf("rule %d: duplicate rulePriority %d", idx+1, *rule.RulePriority)
+		}
+		seen[*rule.RulePriority] = true
+		if len(rule.Selection) == 0 {
+			return fmt.Errorf("rule %d: selection is required", idx+1)
+		}
+		if rule.Action == nil || rule.Action.Type != "expire" {
+			return fmt.Errorf("rule %d: action.type must be \"expire\"", idx+1)
+		}
+	}
+
+	return nil
+}
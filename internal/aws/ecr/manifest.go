@@ -0,0 +1,274 @@
+package ecr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// Manifest media types understood by ParseManifest.
+const (
+	MediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	MediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+var acceptedManifestMediaTypes = []string{
+	MediaTypeOCIIndex,
+	MediaTypeOCIManifest,
+	MediaTypeDockerManifestList,
+	MediaTypeDockerManifest,
+}
+
+// maxImageConfigBytes caps how much of an image config blob is read.
+const maxImageConfigBytes = 4 << 20
+
+// httpClient downloads image config blobs from the pre-signed layer URLs. It
+// is a variable so a local HTTP stand-in can replace S3.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+func (p *Platform) String() string {
+	if p == nil {
+		return "-"
+	}
+	value := fmt.Sprintf("%s/%s", p.OS, p.Architecture)
+	if p.Variant != "" {
+		value += "/" + p.Variant
+	}
+	return value
+}
+
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *Platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is the subset of OCI/Docker manifests and indexes hibiscus
+// renders. Index manifests populate Manifests; single manifests populate
+// Config and Layers.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        *Descriptor  `json:"config,omitempty"`
+	Layers        []Descriptor `json:"layers,omitempty"`
+	Manifests     []Descriptor `json:"manifests,omitempty"`
+}
+
+func (m *Manifest) IsIndex() bool {
+	return m.MediaType == MediaTypeOCIIndex || m.MediaType == MediaTypeDockerManifestList
+}
+
+// ImageConfig is the subset of the OCI image config blob shown in the UI.
+type ImageConfig struct {
+	Created      *time.Time `json:"created,omitempty"`
+	Architecture string     `json:"architecture"`
+	OS           string     `json:"os"`
+	Config       struct {
+		Entrypoint []string          `json:"Entrypoint"`
+		Cmd        []string          `json:"Cmd"`
+		Env        []string          `json:"Env"`
+		WorkingDir string            `json:"WorkingDir"`
+		User       string            `json:"User"`
+		Labels     map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+// ParseManifest decodes a raw manifest. The media type reported by ECR is
+// used when the document itself omits one (OCI manifests may). Media types
+// other than the four above are an error.
+func ParseManifest(raw []byte, mediaType string) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = mediaType
+	}
+	if manifest.MediaType == "" {
+		switch {
+		case len(manifest.Manifests) > 0:
+			manifest.MediaType = MediaTypeOCIIndex
+		default:
+			manifest.MediaType = MediaTypeOCIManifest
+		}
+	}
+	if !slices.Contains(acceptedManifestMediaTypes, manifest.MediaType) {
+		return nil, fmt.Errorf("unsupported manifest media type %q", manifest.MediaType)
+	}
+	return &manifest, nil
+}
+
+// GetImageManifests fetches and parses the manifests of the given digests in
+// a single BatchGetImage call, keyed by digest.
+func GetImageManifests(repositoryName *string, digests []string) (map[string]*Manifest, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	if len(digests) == 0 {
+		return map[string]*Manifest{}, nil
+	}
+
+	ids := make([]types.ImageIdentifier, len(digests))
+	for i := range digests {
+		ids[i] = types.ImageIdentifier{ImageDigest: &digests[i]}
+	}
+
+	resp, err := client.BatchGetImage(context.TODO(), &ecr.BatchGetImageInput{
+		RepositoryName:     repositoryName,
+		ImageIds:           ids,
+		AcceptedMediaTypes: acceptedManifestMediaTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Images) == 0 && len(resp.Failures) > 0 {
+		failure := resp.Failures[0]
+		return nil, fmt.Errorf("batch get image: %s: %s", failure.FailureCode, valueOr(failure.FailureReason))
+	}
+
+	result := make(map[string]*Manifest, len(resp.Images))
+	for _, image := range resp.Images {
+		if image.ImageId == nil || image.ImageId.ImageDigest == nil || image.ImageManifest == nil {
+			continue
+		}
+		manifest, err := ParseManifest([]byte(*image.ImageManifest), valueOr(image.ImageManifestMediaType))
+		if err != nil {
+			return nil, err
+		}
+		result[*image.ImageId.ImageDigest] = manifest
+	}
+
+	return result, nil
+}
+
+// GetImageConfig downloads the image config blob referenced by a manifest.
+// ECR serves blobs through pre-signed URLs from GetDownloadUrlForLayer.
+func GetImageConfig(repositoryName *string, configDigest string) (*ImageConfig, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetDownloadUrlForLayer(context.TODO(), &ecr.GetDownloadUrlForLayerInput{
+		RepositoryName: repositoryName,
+		LayerDigest:    &configDigest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.DownloadUrl == nil {
+		return nil, fmt.Errorf("no download URL for %s", configDigest)
+	}
+
+	return fetchImageConfig(*resp.DownloadUrl)
+}
+
+func fetchImageConfig(url string) (*ImageConfig, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download image config: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageConfigBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ImageConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// ImageManifestDetail bundles everything the image detail pane renders for
+// one digest: the root manifest, the per-platform manifests of an index, and
+// the config of the primary platform.
+type ImageManifestDetail struct {
+	Digest   string
+	Manifest *Manifest
+	// Children holds the platform manifests of an index, keyed by digest.
+	Children map[string]*Manifest
+	// ConfigPlatform is the platform whose config was downloaded; nil for
+	// single-platform images.
+	ConfigPlatform *Platform
+	Config         *ImageConfig
+	// ConfigErr is why Config could not be downloaded. The manifest is still
+	// worth showing without it.
+	ConfigErr error
+}
+
+func DescribeImageManifest(repositoryName *string, digest string) (*ImageManifestDetail, error) {
+	manifests, err := GetImageManifests(repositoryName, []string{digest})
+	if err != nil {
+		return nil, err
+	}
+	root, ok := manifests[digest]
+	if !ok {
+		return nil, fmt.Errorf("manifest %s not found", digest)
+	}
+
+	detail := &ImageManifestDetail{Digest: digest, Manifest: root}
+	configDigest := ""
+	if root.Config != nil {
+		configDigest = root.Config.Digest
+	}
+
+	if root.IsIndex() {
+		digests := make([]string, 0, len(root.Manifests))
+		for _, child := range root.Manifests {
+			digests = append(digests, child.Digest)
+		}
+		detail.Children, err = GetImageManifests(repositoryName, digests)
+		if err != nil {
+			return nil, err
+		}
+
+		// Attestation manifests from buildx are tagged unknown/unknown; the
+		// first real platform is the one worth describing.
+		for _, child := range root.Manifests {
+			if child.Platform == nil || child.Platform.OS == "unknown" {
+				continue
+			}
+			if manifest, ok := detail.Children[child.Digest]; ok && manifest.Config != nil {
+				detail.ConfigPlatform = child.Platform
+				configDigest = manifest.Config.Digest
+				break
+			}
+		}
+	}
+
+	if configDigest != "" {
+		detail.Config, detail.ConfigErr = GetImageConfig(repositoryName, configDigest)
+	}
+
+	return detail, nil
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
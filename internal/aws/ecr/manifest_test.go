@@ -0,0 +1,201 @@
+package ecr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	const (
+		amd64 = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		arm64 = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		cfg   = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+		layer = "sha256:4444444444444444444444444444444444444444444444444444444444444444"
+		base  = "sha256:5555555555555555555555555555555555555555555555555555555555555555"
+	)
+
+	index := func(mediaType, child string) string {
+		return `{"schemaVersion":2,"mediaType":"` + mediaType + `","manifests":[` +
+			`{"mediaType":"` + child + `","digest":"` + amd64 + `","size":1024,"platform":{"architecture":"amd64","os":"linux"}},` +
+			`{"mediaType":"` + child + `","digest":"` + arm64 + `","size":1025,"platform":{"architecture":"arm64","os":"linux","variant":"v8"}}]}`
+	}
+	image := func(mediaType, configType, layerType string) string {
+		return `{"schemaVersion":2,"mediaType":"` + mediaType + `",` +
+			`"config":{"mediaType":"` + configType + `","digest":"` + cfg + `","size":1469},` +
+			`"layers":[{"mediaType":"` + layerType + `","digest":"` + base + `","size":3000000},` +
+			`{"mediaType":"` + layerType + `","digest":"` + layer + `","size":512}]}`
+	}
+	const (
+		ociConfig    = "application/vnd.oci.image.config.v1+json"
+		ociLayer     = "application/vnd.oci.image.layer.v1.tar+gzip"
+		dockerConfig = "application/vnd.docker.container.image.v1+json"
+		dockerLayer  = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	)
+
+	tests := []struct {
+		name          string
+		raw           string
+		mediaType     string
+		wantMediaType string
+		wantIndex     bool
+		wantPlatforms []string
+		wantChildren  []string
+		wantLayers    []string
+		wantErr       string
+	}{
+		{
+			name:          "oci index",
+			raw:           index(MediaTypeOCIIndex, MediaTypeOCIManifest),
+			wantMediaType: MediaTypeOCIIndex,
+			wantIndex:     true,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64/v8"},
+			wantChildren:  []string{amd64, arm64},
+		},
+		{
+			name:          "docker manifest list",
+			raw:           index(MediaTypeDockerManifestList, MediaTypeDockerManifest),
+			wantMediaType: MediaTypeDockerManifestList,
+			wantIndex:     true,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64/v8"},
+			wantChildren:  []string{amd64, arm64},
+		},
+		{
+			name:          "oci manifest",
+			raw:           image(MediaTypeOCIManifest, ociConfig, ociLayer),
+			wantMediaType: MediaTypeOCIManifest,
+			wantLayers:    []string{base, layer},
+		},
+		{
+			name:          "docker v2 manifest",
+			raw:           image(MediaTypeDockerManifest, dockerConfig, dockerLayer),
+			wantMediaType: MediaTypeDockerManifest,
+			wantLayers:    []string{base, layer},
+		},
+		{
+			name:          "media type from ECR",
+			raw:           strings.Replace(image(MediaTypeOCIManifest, ociConfig, ociLayer), `"mediaType":"`+MediaTypeOCIManifest+`",`, "", 1),
+			mediaType:     MediaTypeOCIManifest,
+			wantMediaType: MediaTypeOCIManifest,
+			wantLayers:    []string{base, layer},
+		},
+		{
+			name:          "index without a media type",
+			raw:           strings.Replace(index(MediaTypeOCIIndex, MediaTypeOCIManifest), `"mediaType":"`+MediaTypeOCIIndex+`",`, "", 1),
+			wantMediaType: MediaTypeOCIIndex,
+			wantIndex:     true,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64/v8"},
+			wantChildren:  []string{amd64, arm64},
+		},
+		{
+			name:      "docker schema 1",
+			raw:       `{"schemaVersion":1,"name":"app","tag":"latest","fsLayers":[]}`,
+			mediaType: "application/vnd.docker.distribution.manifest.v1+prettyjws",
+			wantErr:   "unsupported manifest media type",
+		},
+		{
+			name:    "not json",
+			raw:     "<html>",
+			wantErr: "decode manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := ParseManifest([]byte(tt.raw), tt.mediaType)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.MediaType != tt.wantMediaType {
+				t.Errorf("media type = %s, want %s", manifest.MediaType, tt.wantMediaType)
+			}
+			if manifest.IsIndex() != tt.wantIndex {
+				t.Errorf("IsIndex() = %v, want %v", manifest.IsIndex(), tt.wantIndex)
+			}
+
+			var platforms, children, layers []string
+			for _, child := range manifest.Manifests {
+				platforms = append(platforms, child.Platform.String())
+				children = append(children, child.Digest)
+			}
+			for _, l := range manifest.Layers {
+				layers = append(layers, l.Digest)
+			}
+			if !reflect.DeepEqual(platforms, tt.wantPlatforms) {
+				t.Errorf("platforms = %q, want %q", platforms, tt.wantPlatforms)
+			}
+			if !reflect.DeepEqual(children, tt.wantChildren) {
+				t.Errorf("children = %q, want %q", children, tt.wantChildren)
+			}
+			if !reflect.DeepEqual(layers, tt.wantLayers) {
+				t.Errorf("layers = %q, want %q", layers, tt.wantLayers)
+			}
+			if !tt.wantIndex && (manifest.Config == nil || manifest.Config.Digest != cfg) {
+				t.Errorf("config = %+v, want digest %s", manifest.Config, cfg)
+			}
+		})
+	}
+}
+
+func TestFetchImageConfig(t *testing.T) {
+	blob := `{"created":"2024-05-01T10:00:00Z","architecture":"arm64","os":"linux",` +
+		`"config":{"Entrypoint":["/app"],"Cmd":["serve"],"Env":["PORT=8080"],"WorkingDir":"/srv","User":"app","Labels":{"team":"web"}}}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(blob))
+	})
+	mux.HandleFunc("/expired", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Request has expired", http.StatusForbidden)
+	})
+	mux.HandleFunc("/garbage", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	previous := httpClient
+	httpClient = server.Client()
+	defer func() { httpClient = previous }()
+
+	cfg, err := fetchImageConfig(server.URL + "/config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Architecture != "arm64" || cfg.OS != "linux" {
+		t.Errorf("platform = %s/%s, want linux/arm64", cfg.OS, cfg.Architecture)
+	}
+	if cfg.Created == nil || cfg.Created.Year() != 2024 {
+		t.Errorf("created = %v", cfg.Created)
+	}
+	if strings.Join(cfg.Config.Entrypoint, " ") != "/app" || strings.Join(cfg.Config.Cmd, " ") != "serve" {
+		t.Errorf("entrypoint/cmd = %q %q", cfg.Config.Entrypoint, cfg.Config.Cmd)
+	}
+	if cfg.Config.WorkingDir != "/srv" || cfg.Config.User != "app" || cfg.Config.Labels["team"] != "web" {
+		t.Errorf("config = %+v", cfg.Config)
+	}
+
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "/expired", wantErr: "403"},
+		{path: "/garbage", wantErr: "decode image config"},
+		{path: "/missing", wantErr: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := fetchImageConfig(server.URL + tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package ecr
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// Registry describes the registry-level settings of the current account and
+// region.
+type Registry struct {
+	RegistryId  string
+	Replication *types.ReplicationConfiguration
+}
+
+func DescribeRegistry() (*Registry, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.DescribeRegistry(context.TODO(), &ecr.DescribeRegistryInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Registry{
+		RegistryId:  valueOr(resp.RegistryId),
+		Replication: resp.ReplicationConfiguration,
+	}, nil
+}
+
+func DescribePullThroughCacheRules() ([]types.PullThroughCacheRule, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	var (
+		result    []types.PullThroughCacheRule
+		nextToken *string
+		max       = int32(1000)
+	)
+
+	for {
+		input := &ecr.DescribePullThroughCacheRulesInput{
+			MaxResults: &max,
+		}
+		if nextToken != nil {
+			input.NextToken = nextToken
+		}
+
+		resp, err := client.DescribePullThroughCacheRules(context.TODO(), input)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, resp.PullThroughCacheRules...)
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+
+	return result, nil
+}
+
+func DescribeImageReplicationStatus(repositoryName *string, digest string) ([]types.ImageReplicationStatus, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.DescribeImageReplicationStatus(context.TODO(), &ecr.DescribeImageReplicationStatusInput{
+		RepositoryName: repositoryName,
+		ImageId: &types.ImageIdentifier{
+			ImageDigest: &digest,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ReplicationStatuses, nil
+}
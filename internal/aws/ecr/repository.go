@@ -0,0 +1,146 @@
+package ecr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// RepositorySettings describes the options used when creating a repository.
+type RepositorySettings struct {
+	Name           string
+	ImmutableTags  bool
+	ScanOnPush     bool
+	EncryptionType types.EncryptionType
+	// KmsKey is only used with KMS encryption. Leave empty to use the AWS
+	// managed key.
+	KmsKey string
+}
+
+func CreateRepository(settings RepositorySettings) (*types.Repository, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	input := &ecr.CreateRepositoryInput{
+		RepositoryName:     &settings.Name,
+		ImageTagMutability: tagMutability(settings.ImmutableTags),
+		ImageScanningConfiguration: &types.ImageScanningConfiguration{
+			ScanOnPush: settings.ScanOnPush,
+		},
+	}
+
+	encryption := settings.EncryptionType
+	if encryption == "" {
+		encryption = types.EncryptionTypeAes256
+	}
+	input.EncryptionConfiguration = &types.EncryptionConfiguration{
+		EncryptionType: encryption,
+	}
+	if encryption == types.EncryptionTypeKms && settings.KmsKey != "" {
+		input.EncryptionConfiguration.KmsKey = &settings.KmsKey
+	}
+
+	resp, err := client.CreateRepository(context.TODO(), input)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Repository, nil
+}
+
+// DeleteRepository removes a repository. When force is true the repository is
+// deleted even if it still contains images.
+func DeleteRepository(repositoryName *string, force bool) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.DeleteRepository(context.TODO(), &ecr.DeleteRepositoryInput{
+		RepositoryName: repositoryName,
+		Force:          force,
+	})
+	return err
+}
+
+func PutImageTagMutability(repositoryName *string, immutable bool) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.PutImageTagMutability(context.TODO(), &ecr.PutImageTagMutabilityInput{
+		RepositoryName:     repositoryName,
+		ImageTagMutability: tagMutability(immutable),
+	})
+	return err
+}
+
+func PutImageScanningConfiguration(repositoryName *string, scanOnPush bool) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.PutImageScanningConfiguration(context.TODO(), &ecr.PutImageScanningConfigurationInput{
+		RepositoryName: repositoryName,
+		ImageScanningConfiguration: &types.ImageScanningConfiguration{
+			ScanOnPush: scanOnPush,
+		},
+	})
+	return err
+}
+
+// GetRepositoryPolicy returns the repository policy JSON. An empty string is
+// returned when no policy is attached.
+func GetRepositoryPolicy(repositoryName *string) (string, error) {
+	if err := setupClient(); err != nil {
+		return "", err
+	}
+
+	resp, err := client.GetRepositoryPolicy(context.TODO(), &ecr.GetRepositoryPolicyInput{
+		RepositoryName: repositoryName,
+	})
+	if err != nil {
+		var notFound *types.RepositoryPolicyNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if resp.PolicyText == nil {
+		return "", nil
+	}
+	return *resp.PolicyText, nil
+}
+
+func SetRepositoryPolicy(repositoryName *string, policy string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.SetRepositoryPolicy(context.TODO(), &ecr.SetRepositoryPolicyInput{
+		RepositoryName: repositoryName,
+		PolicyText:     &policy,
+	})
+	return err
+}
+
+func DeleteRepositoryPolicy(repositoryName *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+
+	_, err := client.DeleteRepositoryPolicy(context.TODO(), &ecr.DeleteRepositoryPolicyInput{
+		RepositoryName: repositoryName,
+	})
+	return err
+}
+
+func tagMutability(immutable bool) types.ImageTagMutability {
+	if immutable {
+		return types.ImageTagMutabilityImmutable
+	}
+	return types.ImageTagMutabilityMutable
+}
@@ -0,0 +1,86 @@
+package ecr
+
+import (
+	"sync"
+	"time"
+)
+
+// usageWorkers bounds how many repositories are scanned concurrently so large
+// registries do not trip DescribeImages throttling.
+const usageWorkers = 8
+
+// RepositoryUsage aggregates storage statistics across every image (tagged
+// and untagged) of a repository. Sizes are summed per image as reported by
+// ECR, so layers shared between images are counted more than once.
+type RepositoryUsage struct {
+	ImageCount int
+	TotalBytes int64
+	OldestPush *time.Time
+	NewestPush *time.Time
+	LastPull   *time.Time
+}
+
+// SummarizeRepository pages through all images of a repository and
+// aggregates their storage statistics.
+func SummarizeRepository(repositoryName *string) (RepositoryUsage, error) {
+	images, err := listImages(repositoryName, nil)
+	if err != nil {
+		return RepositoryUsage{}, err
+	}
+
+	var usage RepositoryUsage
+	for _, image := range images {
+		usage.ImageCount++
+		if image.ImageSizeInBytes != nil {
+			usage.TotalBytes += *image.ImageSizeInBytes
+		}
+		if pushed := image.ImagePushedAt; pushed != nil {
+			if usage.OldestPush == nil || pushed.Before(*usage.OldestPush) {
+				usage.OldestPush = pushed
+			}
+			if usage.NewestPush == nil || pushed.After(*usage.NewestPush) {
+				usage.NewestPush = pushed
+			}
+		}
+		if pulled := image.LastRecordedPullTime; pulled != nil {
+			if usage.LastPull == nil || pulled.After(*usage.LastPull) {
+				usage.LastPull = pulled
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// SummarizeRepositories runs SummarizeRepository for every repository with a
+// bounded worker pool. onResult is invoked from the worker goroutines as each
+// repository finishes; a per-repository error is reported through it rather
+// than aborting the whole scan.
+func SummarizeRepositories(names []string, onResult func(name string, usage RepositoryUsage, err error)) {
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+
+	for range usageWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				repoName := name
+				usage, err := SummarizeRepository(&repoName)
+				onResult(repoName, usage, err)
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// MonthlyStorageCost estimates the monthly storage bill for the given size at
+// a price per GB-month.
+func MonthlyStorageCost(bytes int64, pricePerGB float64) float64 {
+	return float64(bytes) / (1024 * 1024 * 1024) * pricePerGB
+}
@@ -9,19 +9,43 @@ import (
 	"github.com/jaehong21/hibiscus/internal/aws"
 )
 
+// ECR Public only serves its API from us-east-1, regardless of where the
+// repositories are consumed.
+// https://docs.aws.amazon.com/general/latest/gr/ecr-public.html
+const Region = "us-east-1"
+
 var client *ecrpublic.Client
 
 func DescribePublicRepositories() ([]types.Repository, error) {
 	if err := setupClient(); err != nil {
 		return nil, err
 	}
-	repositories, err := client.DescribeRepositories(context.TODO(), &ecrpublic.DescribeRepositoriesInput{})
-	if err != nil {
-		return nil, err
-	}
+	var (
+		result    []types.Repository
+		nextToken *string
+		max       = int32(1000)
+	)
+
+	for {
+		input := &ecrpublic.DescribeRepositoriesInput{
+			MaxResults: &max,
+		}
+		if nextToken != nil {
+			input.NextToken = nextToken
+		}
+
+		resp, err := client.DescribeRepositories(context.TODO(), input)
+		if err != nil {
+			return nil, err
+		}
 
-	var result []types.Repository
-	result = append(result, repositories.Repositories...)
+		result = append(result, resp.Repositories...)
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			break
+		}
+		nextToken = resp.NextToken
+	}
 
 	sort.Slice(result, func(i, j int) bool {
 		return result[i].CreatedAt.After(*result[j].CreatedAt)
@@ -34,19 +58,37 @@ func DescribePublicImages(repositoryName *string) ([]types.ImageDetail, error) {
 	if err := setupClient(); err != nil {
 		return nil, err
 	}
-	images, err := client.DescribeImages(context.TODO(), &ecrpublic.DescribeImagesInput{
-		RepositoryName: repositoryName,
-	})
-	if err != nil {
-		return nil, err
-	}
+	var (
+		result    []types.ImageDetail
+		nextToken *string
+		max       = int32(1000)
+	)
+
+	for {
+		input := &ecrpublic.DescribeImagesInput{
+			RepositoryName: repositoryName,
+			MaxResults:     &max,
+		}
+		if nextToken != nil {
+			input.NextToken = nextToken
+		}
 
-	var result []types.ImageDetail
-	for _, image := range images.ImageDetails {
-		// list images with tag only
-		if len(image.ImageTags) > 0 {
-			result = append(result, image)
+		resp, err := client.DescribeImages(context.TODO(), input)
+		if err != nil {
+			return nil, err
 		}
+
+		for _, image := range resp.ImageDetails {
+			// list images with tag only
+			if len(image.ImageTags) > 0 {
+				result = append(result, image)
+			}
+		}
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			break
+		}
+		nextToken = resp.NextToken
 	}
 
 	// sort by ImagePushedAt
@@ -57,6 +99,22 @@ func DescribePublicImages(repositoryName *string) ([]types.ImageDetail, error) {
 	return result, nil
 }
 
+// GetRepositoryCatalogData returns the gallery metadata (description,
+// architectures, operating systems, ...) of a public repository.
+func GetRepositoryCatalogData(repositoryName *string) (*types.RepositoryCatalogData, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	resp, err := client.GetRepositoryCatalogData(context.TODO(), &ecrpublic.GetRepositoryCatalogDataInput{
+		RepositoryName: repositoryName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CatalogData, nil
+}
+
 func setupClient() error {
 	if client != nil {
 		return nil
@@ -67,6 +125,8 @@ func setupClient() error {
 		return err
 	}
 
-	client = ecrpublic.NewFromConfig(cfg)
+	client = ecrpublic.NewFromConfig(cfg, func(o *ecrpublic.Options) {
+		o.Region = Region
+	})
 	return nil
 }
@@ -0,0 +1,68 @@
+// Package elb wraps the classic elasticloadbalancing API for legacy Classic
+// Load Balancers; ALB, NLB and GWLB live in internal/aws/elbv2.
+package elb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/jaehong21/hibiscus/internal/aws"
+)
+
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing
+var client *elasticloadbalancing.Client
+
+func DescribeLoadBalancers() ([]types.LoadBalancerDescription, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		lbs      []types.LoadBalancerDescription
+		marker   *string
+		pageSize = int32(400)
+	)
+	for {
+		output, err := client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancing.DescribeLoadBalancersInput{
+			Marker:   marker,
+			PageSize: &pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		lbs = append(lbs, output.LoadBalancerDescriptions...)
+		if output.NextMarker == nil {
+			break
+		}
+		marker = output.NextMarker
+	}
+
+	return lbs, nil
+}
+
+func DescribeInstanceHealth(loadBalancerName *string) ([]types.InstanceState, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	health, err := client.DescribeInstanceHealth(context.TODO(), &elasticloadbalancing.DescribeInstanceHealthInput{
+		LoadBalancerName: loadBalancerName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return health.InstanceStates, nil
+}
+
+func setupClient() error {
+	if client != nil {
+		return nil
+	}
+	cfg, err := aws.GetAWSConfig(context.Background())
+	if err != nil {
+		return err
+	}
+	client = elasticloadbalancing.NewFromConfig(cfg)
+	return nil
+}
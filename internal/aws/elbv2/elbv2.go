@@ -51,6 +51,80 @@ func DescribeRules(listenerArn *string) ([]types.Rule, error) {
 	return rules.Rules, nil
 }
 
+func DescribeTargetGroups(targetGroupArns []string) ([]types.TargetGroup, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	if len(targetGroupArns) == 0 {
+		return nil, nil
+	}
+	groups, err := client.DescribeTargetGroups(context.TODO(), &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		TargetGroupArns: targetGroupArns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups.TargetGroups, nil
+}
+
+func DescribeTargetHealth(targetGroupArn *string) ([]types.TargetHealthDescription, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	health, err := client.DescribeTargetHealth(context.TODO(), &elasticloadbalancingv2.DescribeTargetHealthInput{
+		TargetGroupArn: targetGroupArn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return health.TargetHealthDescriptions, nil
+}
+
+func DescribeLoadBalancerAttributes(loadBalancerArn *string) ([]types.LoadBalancerAttribute, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	attributes, err := client.DescribeLoadBalancerAttributes(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: loadBalancerArn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attributes.Attributes, nil
+}
+
+// DescribeListenerCertificates returns the default certificate and every
+// additional certificate attached to an HTTPS or TLS listener.
+func DescribeListenerCertificates(listenerArn *string) ([]types.Certificate, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		certificates []types.Certificate
+		marker       *string
+	)
+	for {
+		output, err := client.DescribeListenerCertificates(context.TODO(), &elasticloadbalancingv2.DescribeListenerCertificatesInput{
+			ListenerArn: listenerArn,
+			Marker:      marker,
+		})
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, output.Certificates...)
+		if output.NextMarker == nil {
+			break
+		}
+		marker = output.NextMarker
+	}
+
+	return certificates, nil
+}
+
 func setupClient() error {
 	if client != nil {
 		return nil
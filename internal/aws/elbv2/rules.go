@@ -0,0 +1,558 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// Condition field names accepted by listener rules.
+const (
+	ConditionHostHeader  = "host-header"
+	ConditionPathPattern = "path-pattern"
+	ConditionHTTPHeader  = "http-header"
+	ConditionQueryString = "query-string"
+	ConditionSourceIP    = "source-ip"
+)
+
+// RuleSpec is a flattened, editable form of a listener rule. Conditions and
+// actions the editor does not understand (for example http-request-method or
+// authenticate-oidc) are carried through untouched so that modifying a rule
+// never silently drops them.
+type RuleSpec struct {
+	Priority int32
+
+	HostHeaders      []string
+	PathPatterns     []string
+	HTTPHeaderName   string
+	HTTPHeaderValues []string
+	QueryStrings     []types.QueryStringKeyValuePair
+	SourceIPs        []string
+
+	Action ActionSpec
+
+	ExtraConditions []types.RuleCondition
+	// PreActions precede the final action, e.g. authenticate-cognito.
+	PreActions []types.Action
+}
+
+// ActionSpec describes the final (routing) action of a rule.
+type ActionSpec struct {
+	Type types.ActionTypeEnum
+
+	// forward
+	TargetGroups []types.TargetGroupTuple
+	Stickiness   *types.TargetGroupStickinessConfig
+
+	// redirect
+	RedirectProtocol   string
+	RedirectHost       string
+	RedirectPort       string
+	RedirectPath       string
+	RedirectQuery      string
+	RedirectStatusCode types.RedirectActionStatusCodeEnum
+
+	// fixed-response
+	FixedStatusCode  string
+	FixedContentType string
+	FixedMessageBody string
+}
+
+// RuleSpecFromRule converts a described rule into its editable form.
+func RuleSpecFromRule(rule types.Rule) RuleSpec {
+	var spec RuleSpec
+	if rule.Priority != nil {
+		if priority, err := strconv.Atoi(*rule.Priority); err == nil {
+			spec.Priority = int32(priority)
+		}
+	}
+
+	queryStringSeen := false
+	for _, cond := range rule.Conditions {
+		switch aws.ToString(cond.Field) {
+		case ConditionHostHeader:
+			if cond.HostHeaderConfig != nil {
+				spec.HostHeaders = append(spec.HostHeaders, cond.HostHeaderConfig.Values...)
+			} else {
+				spec.HostHeaders = append(spec.HostHeaders, cond.Values...)
+			}
+		case ConditionPathPattern:
+			if cond.PathPatternConfig != nil {
+				spec.PathPatterns = append(spec.PathPatterns, cond.PathPatternConfig.Values...)
+			} else {
+				spec.PathPatterns = append(spec.PathPatterns, cond.Values...)
+			}
+		case ConditionHTTPHeader:
+			// Only one http-header condition is editable; further ones are kept as-is.
+			if cond.HttpHeaderConfig != nil && spec.HTTPHeaderName == "" {
+				spec.HTTPHeaderName = aws.ToString(cond.HttpHeaderConfig.HttpHeaderName)
+				spec.HTTPHeaderValues = append(spec.HTTPHeaderValues, cond.HttpHeaderConfig.Values...)
+			} else {
+				spec.ExtraConditions = append(spec.ExtraConditions, cond)
+			}
+		case ConditionQueryString:
+			// Separate query-string conditions are ANDed while the values of
+			// one are ORed, so only the first is editable and the rest are
+			// kept as-is.
+			if cond.QueryStringConfig != nil && !queryStringSeen {
+				queryStringSeen = true
+				spec.QueryStrings = append(spec.QueryStrings, cond.QueryStringConfig.Values...)
+			} else {
+				spec.ExtraConditions = append(spec.ExtraConditions, cond)
+			}
+		case ConditionSourceIP:
+			if cond.SourceIpConfig != nil {
+				spec.SourceIPs = append(spec.SourceIPs, cond.SourceIpConfig.Values...)
+			}
+		default:
+			spec.ExtraConditions = append(spec.ExtraConditions, cond)
+		}
+	}
+
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case types.ActionTypeEnumForward:
+			spec.Action.Type = action.Type
+			if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 0 {
+				spec.Action.TargetGroups = append(spec.Action.TargetGroups, action.ForwardConfig.TargetGroups...)
+				spec.Action.Stickiness = action.ForwardConfig.TargetGroupStickinessConfig
+			} else if action.TargetGroupArn != nil {
+				spec.Action.TargetGroups = []types.TargetGroupTuple{{TargetGroupArn: action.TargetGroupArn}}
+			}
+		case types.ActionTypeEnumRedirect:
+			spec.Action.Type = action.Type
+			if cfg := action.RedirectConfig; cfg != nil {
+				spec.Action.RedirectProtocol = aws.ToString(cfg.Protocol)
+				spec.Action.RedirectHost = aws.ToString(cfg.Host)
+				spec.Action.RedirectPort = aws.ToString(cfg.Port)
+				spec.Action.RedirectPath = aws.ToString(cfg.Path)
+				spec.Action.RedirectQuery = aws.ToString(cfg.Query)
+				spec.Action.RedirectStatusCode = cfg.StatusCode
+			}
+		case types.ActionTypeEnumFixedResponse:
+			spec.Action.Type = action.Type
+			if cfg := action.FixedResponseConfig; cfg != nil {
+				spec.Action.FixedStatusCode = aws.ToString(cfg.StatusCode)
+				spec.Action.FixedContentType = aws.ToString(cfg.ContentType)
+				spec.Action.FixedMessageBody = aws.ToString(cfg.MessageBody)
+			}
+		default:
+			spec.PreActions = append(spec.PreActions, ResendableAction(action))
+		}
+	}
+
+	return spec
+}
+
+// ResendableAction prepares a described action to be sent back to the API.
+// Describe calls never return the OIDC client secret, so an
+// authenticate-oidc action is told to keep the existing one.
+func ResendableAction(action types.Action) types.Action {
+	if action.AuthenticateOidcConfig != nil {
+		cfg := *action.AuthenticateOidcConfig
+		cfg.ClientSecret = nil
+		cfg.UseExistingClientSecret = aws.Bool(true)
+		action.AuthenticateOidcConfig = &cfg
+	}
+	return action
+}
+
+// Conditions builds the rule conditions for the API.
+func (r RuleSpec) Conditions() []types.RuleCondition {
+	var conditions []types.RuleCondition
+	if len(r.HostHeaders) > 0 {
+		conditions = append(conditions, types.RuleCondition{
+			Field:            aws.String(ConditionHostHeader),
+			HostHeaderConfig: &types.HostHeaderConditionConfig{Values: r.HostHeaders},
+		})
+	}
+	if len(r.PathPatterns) > 0 {
+		conditions = append(conditions, types.RuleCondition{
+			Field:             aws.String(ConditionPathPattern),
+			PathPatternConfig: &types.PathPatternConditionConfig{Values: r.PathPatterns},
+		})
+	}
+	if r.HTTPHeaderName != "" && len(r.HTTPHeaderValues) > 0 {
+		conditions = append(conditions, types.RuleCondition{
+			Field: aws.String(ConditionHTTPHeader),
+			HttpHeaderConfig: &types.HttpHeaderConditionConfig{
+				HttpHeaderName: aws.String(r.HTTPHeaderName),
+				Values:         r.HTTPHeaderValues,
+			},
+		})
+	}
+	if len(r.QueryStrings) > 0 {
+		conditions = append(conditions, types.RuleCondition{
+			Field:             aws.String(ConditionQueryString),
+			QueryStringConfig: &types.QueryStringConditionConfig{Values: r.QueryStrings},
+		})
+	}
+	if len(r.SourceIPs) > 0 {
+		conditions = append(conditions, types.RuleCondition{
+			Field:          aws.String(ConditionSourceIP),
+			SourceIpConfig: &types.SourceIpConditionConfig{Values: r.SourceIPs},
+		})
+	}
+	return append(conditions, r.ExtraConditions...)
+}
+
+// Actions builds the rule actions for the API, keeping any preceding
+// authenticate actions in front of the routing action.
+func (r RuleSpec) Actions() []types.Action {
+	actions := make([]types.Action, 0, len(r.PreActions)+1)
+	actions = append(actions, r.PreActions...)
+
+	final := types.Action{Type: r.Action.Type}
+	switch r.Action.Type {
+	case types.ActionTypeEnumForward:
+		final.ForwardConfig = &types.ForwardActionConfig{
+			TargetGroups:                r.Action.TargetGroups,
+			TargetGroupStickinessConfig: r.Action.Stickiness,
+		}
+	case types.ActionTypeEnumRedirect:
+		final.RedirectConfig = &types.RedirectActionConfig{
+			StatusCode: r.Action.RedirectStatusCode,
+			Protocol:   optionalString(r.Action.RedirectProtocol),
+			Host:       optionalString(r.Action.RedirectHost),
+			Port:       optionalString(r.Action.RedirectPort),
+			Path:       optionalString(r.Action.RedirectPath),
+			Query:      optionalString(r.Action.RedirectQuery),
+		}
+	case types.ActionTypeEnumFixedResponse:
+		final.FixedResponseConfig = &types.FixedResponseActionConfig{
+			StatusCode:  aws.String(r.Action.FixedStatusCode),
+			ContentType: optionalString(r.Action.FixedContentType),
+			MessageBody: optionalString(r.Action.FixedMessageBody),
+		}
+	}
+
+	// Order is required once a rule has more than one action, and the
+	// routing action must come last.
+	if len(r.PreActions) > 0 {
+		var last int32
+		for idx := range actions {
+			if actions[idx].Order == nil {
+				actions[idx].Order = aws.Int32(max(int32(idx+1), last+1))
+			}
+			last = max(last, *actions[idx].Order)
+		}
+		final.Order = aws.Int32(last + 1)
+	}
+	return append(actions, final)
+}
+
+// Validate performs the checks ELB would otherwise reject with a less
+// helpful error.
+func (r RuleSpec) Validate() error {
+	if r.Priority < 1 || r.Priority > 50000 {
+		return fmt.Errorf("priority must be between 1 and 50000")
+	}
+	if len(r.Conditions()) == 0 {
+		return fmt.Errorf("at least one condition is required")
+	}
+	if r.HTTPHeaderName != "" && len(r.HTTPHeaderValues) == 0 {
+		return fmt.Errorf("http-header %s needs at least one value", r.HTTPHeaderName)
+	}
+	if r.HTTPHeaderName == "" && len(r.HTTPHeaderValues) > 0 {
+		return fmt.Errorf("http-header values need a header name")
+	}
+
+	switch r.Action.Type {
+	case types.ActionTypeEnumForward:
+		if len(r.Action.TargetGroups) == 0 {
+			return fmt.Errorf("forward action needs at least one target group")
+		}
+	case types.ActionTypeEnumRedirect:
+		if r.Action.RedirectStatusCode == "" {
+			return fmt.Errorf("redirect action needs a status code")
+		}
+		if r.Action.RedirectProtocol == "" && r.Action.RedirectHost == "" && r.Action.RedirectPort == "" &&
+			r.Action.RedirectPath == "" && r.Action.RedirectQuery == "" {
+			return fmt.Errorf("redirect action must change at least one URL component")
+		}
+	case types.ActionTypeEnumFixedResponse:
+		code, err := strconv.Atoi(r.Action.FixedStatusCode)
+		if err != nil || code < 200 || code > 599 || (code >= 300 && code < 400) {
+			return fmt.Errorf("fixed-response status code must be 2XX, 4XX or 5XX")
+		}
+	default:
+		return fmt.Errorf("unsupported action type %q", r.Action.Type)
+	}
+	return nil
+}
+
+// Describe renders the rule as ordered "field: value" lines, used for the
+// confirmation diff shown before a change is applied.
+func (r RuleSpec) Describe() []string {
+	lines := []string{fmt.Sprintf("priority: %d", r.Priority)}
+	if len(r.HostHeaders) > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %s", ConditionHostHeader, strings.Join(r.HostHeaders, ", ")))
+	}
+	if len(r.PathPatterns) > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %s", ConditionPathPattern, strings.Join(r.PathPatterns, ", ")))
+	}
+	if r.HTTPHeaderName != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s = %s", ConditionHTTPHeader, r.HTTPHeaderName, strings.Join(r.HTTPHeaderValues, ", ")))
+	}
+	if len(r.QueryStrings) > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %s", ConditionQueryString, FormatQueryStrings(r.QueryStrings)))
+	}
+	if len(r.SourceIPs) > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %s", ConditionSourceIP, strings.Join(r.SourceIPs, ", ")))
+	}
+	for _, cond := range r.ExtraConditions {
+		lines = append(lines, fmt.Sprintf("%s: (unchanged)", aws.ToString(cond.Field)))
+	}
+	for _, action := range r.PreActions {
+		lines = append(lines, fmt.Sprintf("action: %s (unchanged)", action.Type))
+	}
+
+	switch r.Action.Type {
+	case types.ActionTypeEnumForward:
+		lines = append(lines, fmt.Sprintf("action: forward → %s", FormatTargetGroups(r.Action.TargetGroups)))
+	case types.ActionTypeEnumRedirect:
+		lines = append(lines, fmt.Sprintf("action: redirect %s → %s", r.Action.RedirectStatusCode, r.Action.RedirectTarget()))
+	case types.ActionTypeEnumFixedResponse:
+		line := fmt.Sprintf("action: fixed-response %s", r.Action.FixedStatusCode)
+		if r.Action.FixedContentType != "" {
+			line += " " + r.Action.FixedContentType
+		}
+		lines = append(lines, line)
+		if r.Action.FixedMessageBody != "" {
+			lines = append(lines, fmt.Sprintf("body: %s", r.Action.FixedMessageBody))
+		}
+	}
+	return lines
+}
+
+// RedirectTarget renders the redirect URL template, leaving unset components
+// as the #{...} placeholders ELB substitutes from the request.
+func (a ActionSpec) RedirectTarget() string {
+	protocol := valueOrDefault(a.RedirectProtocol, "#{protocol}")
+	host := valueOrDefault(a.RedirectHost, "#{host}")
+	port := valueOrDefault(a.RedirectPort, "#{port}")
+	path := valueOrDefault(a.RedirectPath, "/#{path}")
+	query := valueOrDefault(a.RedirectQuery, "#{query}")
+	return fmt.Sprintf("%s://%s:%s%s?%s", strings.ToLower(protocol), host, port, path, query)
+}
+
+// ParseList splits a comma separated form value into trimmed, non-empty items.
+func ParseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ParseQueryStrings parses "key=value" pairs; a bare "value" matches the
+// value under any key.
+func ParseQueryStrings(value string) []types.QueryStringKeyValuePair {
+	var pairs []types.QueryStringKeyValuePair
+	for _, item := range ParseList(value) {
+		pair := types.QueryStringKeyValuePair{}
+		if k, v, ok := strings.Cut(item, "="); ok {
+			if k = strings.TrimSpace(k); k != "" {
+				pair.Key = aws.String(k)
+			}
+			pair.Value = aws.String(strings.TrimSpace(v))
+		} else {
+			pair.Value = aws.String(item)
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+// FormatQueryStrings is the inverse of ParseQueryStrings.
+func FormatQueryStrings(pairs []types.QueryStringKeyValuePair) string {
+	items := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.Key != nil {
+			items = append(items, fmt.Sprintf("%s=%s", *pair.Key, aws.ToString(pair.Value)))
+		} else {
+			items = append(items, aws.ToString(pair.Value))
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// ParseTargetGroups resolves "name[=weight]" items (or full ARNs) against the
+// known target groups.
+func ParseTargetGroups(value string, groups []types.TargetGroup) ([]types.TargetGroupTuple, error) {
+	byName := make(map[string]string, len(groups))
+	for _, group := range groups {
+		byName[aws.ToString(group.TargetGroupName)] = aws.ToString(group.TargetGroupArn)
+	}
+
+	items := ParseList(value)
+	tuples := make([]types.TargetGroupTuple, 0, len(items))
+	for _, item := range items {
+		ref, weightText, hasWeight := strings.Cut(item, "=")
+		ref = strings.TrimSpace(ref)
+
+		arn := ref
+		if !strings.HasPrefix(ref, "arn:") {
+			var ok bool
+			if arn, ok = byName[ref]; !ok {
+				return nil, fmt.Errorf("unknown target group %q", ref)
+			}
+		}
+
+		tuple := types.TargetGroupTuple{TargetGroupArn: aws.String(arn)}
+		if hasWeight {
+			weight, err := strconv.Atoi(strings.TrimSpace(weightText))
+			if err != nil || weight < 0 || weight > 999 {
+				return nil, fmt.Errorf("weight for %s must be between 0 and 999", ref)
+			}
+			tuple.Weight = aws.Int32(int32(weight))
+		}
+		tuples = append(tuples, tuple)
+	}
+	return tuples, nil
+}
+
+// FormatTargetGroups is the inverse of ParseTargetGroups, using names.
+func FormatTargetGroups(tuples []types.TargetGroupTuple) string {
+	items := make([]string, 0, len(tuples))
+	for _, tuple := range tuples {
+		item := TargetGroupName(aws.ToString(tuple.TargetGroupArn))
+		if tuple.Weight != nil && len(tuples) > 1 {
+			item = fmt.Sprintf("%s=%d", item, *tuple.Weight)
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, ", ")
+}
+
+// TargetGroupName extracts the name from an ARN such as
+// arn:aws:elasticloadbalancing:...:targetgroup/<name>/<id>.
+func TargetGroupName(arn string) string {
+	_, resource, ok := strings.Cut(arn, ":targetgroup/")
+	if !ok {
+		return arn
+	}
+	name, _, _ := strings.Cut(resource, "/")
+	return name
+}
+
+func ListTargetGroups() ([]types.TargetGroup, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		groups []types.TargetGroup
+		marker *string
+	)
+	for {
+		output, err := client.DescribeTargetGroups(context.TODO(), &elasticloadbalancingv2.DescribeTargetGroupsInput{
+			Marker:   marker,
+			PageSize: aws.Int32(400),
+		})
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, output.TargetGroups...)
+		if output.NextMarker == nil {
+			break
+		}
+		marker = output.NextMarker
+	}
+
+	return groups, nil
+}
+
+func CreateRule(listenerArn *string, spec RuleSpec) (*types.Rule, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	output, err := client.CreateRule(context.TODO(), &elasticloadbalancingv2.CreateRuleInput{
+		ListenerArn: listenerArn,
+		Priority:    aws.Int32(spec.Priority),
+		Conditions:  spec.Conditions(),
+		Actions:     spec.Actions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Rules) == 0 {
+		return nil, nil
+	}
+
+	return &output.Rules[0], nil
+}
+
+// ModifyRule replaces the conditions and actions of a rule. Priorities are
+// changed separately through SetRulePriorities.
+func ModifyRule(ruleArn *string, spec RuleSpec) (*types.Rule, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	output, err := client.ModifyRule(context.TODO(), &elasticloadbalancingv2.ModifyRuleInput{
+		RuleArn:    ruleArn,
+		Conditions: spec.Conditions(),
+		Actions:    spec.Actions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Rules) == 0 {
+		return nil, nil
+	}
+
+	return &output.Rules[0], nil
+}
+
+func DeleteRule(ruleArn *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.DeleteRule(context.TODO(), &elasticloadbalancingv2.DeleteRuleInput{
+		RuleArn: ruleArn,
+	})
+	return err
+}
+
+// SetRulePriorities applies all priority changes in a single call so that
+// swapping two rules does not conflict halfway through.
+func SetRulePriorities(priorities map[string]int32) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	if len(priorities) == 0 {
+		return nil
+	}
+	pairs := make([]types.RulePriorityPair, 0, len(priorities))
+	for arn, priority := range priorities {
+		pairs = append(pairs, types.RulePriorityPair{
+			RuleArn:  aws.String(arn),
+			Priority: aws.Int32(priority),
+		})
+	}
+	_, err := client.SetRulePriorities(context.TODO(), &elasticloadbalancingv2.SetRulePrioritiesInput{
+		RulePriorities: pairs,
+	})
+	return err
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
+
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,191 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// MaxTargetGroupWeight is the largest weight ELB accepts for a target group
+// in a forward action.
+const MaxTargetGroupWeight = 999
+
+// Weights maps target group ARNs to forward weights.
+type Weights map[string]int32
+
+// ForwardWeights reads the target group weights of the first forward action.
+// A forward action that names a single target group without ForwardConfig
+// receives all traffic, which is reported as weight 1.
+func ForwardWeights(actions []types.Action) (Weights, bool) {
+	for _, action := range actions {
+		if action.Type != types.ActionTypeEnumForward {
+			continue
+		}
+		weights := Weights{}
+		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 0 {
+			for _, tg := range action.ForwardConfig.TargetGroups {
+				if tg.TargetGroupArn == nil {
+					continue
+				}
+				// An omitted weight defaults to 1 on the ELB side.
+				weights[*tg.TargetGroupArn] = aws.ToInt32(tg.Weight)
+				if tg.Weight == nil {
+					weights[*tg.TargetGroupArn] = 1
+				}
+			}
+		} else if action.TargetGroupArn != nil {
+			weights[*action.TargetGroupArn] = 1
+		}
+		return weights, len(weights) > 0
+	}
+	return nil, false
+}
+
+// ForwardStickiness returns the target group stickiness of the first forward
+// action, if any.
+func ForwardStickiness(actions []types.Action) *types.TargetGroupStickinessConfig {
+	for _, action := range actions {
+		if action.Type == types.ActionTypeEnumForward && action.ForwardConfig != nil {
+			return action.ForwardConfig.TargetGroupStickinessConfig
+		}
+	}
+	return nil
+}
+
+// WithForwardWeights returns a copy of actions whose first forward action
+// uses the given weights. Other actions and the stickiness configuration are
+// preserved; the legacy TargetGroupArn field is cleared because ELB rejects
+// it alongside a ForwardConfig with several target groups.
+func WithForwardWeights(actions []types.Action, weights Weights, order []string) []types.Action {
+	updated := make([]types.Action, len(actions))
+	copy(updated, actions)
+
+	for idx, action := range updated {
+		if action.Type != types.ActionTypeEnumForward {
+			continue
+		}
+		tuples := make([]types.TargetGroupTuple, 0, len(order))
+		for _, arn := range order {
+			tuples = append(tuples, types.TargetGroupTuple{
+				TargetGroupArn: aws.String(arn),
+				Weight:         aws.Int32(weights[arn]),
+			})
+		}
+		config := &types.ForwardActionConfig{TargetGroups: tuples}
+		if action.ForwardConfig != nil {
+			config.TargetGroupStickinessConfig = action.ForwardConfig.TargetGroupStickinessConfig
+		}
+		action.ForwardConfig = config
+		action.TargetGroupArn = nil
+		updated[idx] = action
+		break
+	}
+	return updated
+}
+
+// ShiftSteps interpolates linearly from one set of weights to another. The
+// last step always equals to, so rounding never leaves traffic behind.
+func ShiftSteps(from, to Weights, steps int) []Weights {
+	if steps < 1 {
+		steps = 1
+	}
+	plan := make([]Weights, 0, steps)
+	for step := 1; step <= steps; step++ {
+		weights := Weights{}
+		for arn, target := range to {
+			start := from[arn]
+			delta := float64(target-start) * float64(step) / float64(steps)
+			weights[arn] = start + int32(roundHalfAway(delta))
+		}
+		plan = append(plan, weights)
+	}
+	return plan
+}
+
+// Equal reports whether both weight sets assign the same weights.
+func (w Weights) Equal(other Weights) bool {
+	if len(w) != len(other) {
+		return false
+	}
+	for arn, weight := range w {
+		if value, ok := other[arn]; !ok || value != weight {
+			return false
+		}
+	}
+	return true
+}
+
+// Total sums every weight.
+func (w Weights) Total() int32 {
+	var total int32
+	for _, weight := range w {
+		total += weight
+	}
+	return total
+}
+
+// Describe renders the weights as "name=weight (pct%)" in the given order.
+func (w Weights) Describe(order []string) string {
+	total := w.Total()
+	parts := make([]string, 0, len(order))
+	for _, arn := range order {
+		share := 0.0
+		if total > 0 {
+			share = float64(w[arn]) / float64(total) * 100
+		}
+		parts = append(parts, fmt.Sprintf("%s=%d (%.0f%%)", TargetGroupName(arn), w[arn], share))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Clone returns an independent copy of the weights.
+func (w Weights) Clone() Weights {
+	clone := make(Weights, len(w))
+	for arn, weight := range w {
+		clone[arn] = weight
+	}
+	return clone
+}
+
+func ModifyListenerDefaultActions(listenerArn *string, actions []types.Action) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.ModifyListener(context.TODO(), &elasticloadbalancingv2.ModifyListenerInput{
+		ListenerArn:    listenerArn,
+		DefaultActions: resendableActions(actions),
+	})
+	return err
+}
+
+// ModifyRuleActions replaces only the actions of a rule; its conditions are
+// left untouched.
+func ModifyRuleActions(ruleArn *string, actions []types.Action) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.ModifyRule(context.TODO(), &elasticloadbalancingv2.ModifyRuleInput{
+		RuleArn: ruleArn,
+		Actions: resendableActions(actions),
+	})
+	return err
+}
+
+func resendableActions(actions []types.Action) []types.Action {
+	resendable := make([]types.Action, 0, len(actions))
+	for _, action := range actions {
+		resendable = append(resendable, ResendableAction(action))
+	}
+	return resendable
+}
+
+func roundHalfAway(value float64) float64 {
+	if value < 0 {
+		return -float64(int64(-value + 0.5))
+	}
+	return float64(int64(value + 0.5))
+}
@@ -0,0 +1,88 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// MaxQueryGroups is the most log groups a single Logs Insights query can
+// search.
+const MaxQueryGroups = 50
+
+// QueryResults is a snapshot of a Logs Insights query. Rows keep the field
+// order of the query; Done reports whether the query stopped running.
+type QueryResults struct {
+	Status     types.QueryStatus
+	Rows       [][]types.ResultField
+	Statistics *types.QueryStatistics
+}
+
+// Done reports whether the query finished, successfully or not.
+func (r *QueryResults) Done() bool {
+	switch r.Status {
+	case types.QueryStatusScheduled, types.QueryStatusRunning:
+		return false
+	}
+	return true
+}
+
+// StartQuery starts a Logs Insights query over groupNames between start and
+// end and returns its ID.
+func StartQuery(groupNames []string, query string, start, end time.Time) (string, error) {
+	if err := setupClient(); err != nil {
+		return "", err
+	}
+	if len(groupNames) == 0 {
+		return "", fmt.Errorf("select at least one log group")
+	}
+	if len(groupNames) > MaxQueryGroups {
+		return "", fmt.Errorf("a query can search at most %d log groups, %d selected", MaxQueryGroups, len(groupNames))
+	}
+
+	startTime, endTime := start.Unix(), end.Unix()
+	resp, err := client.StartQuery(context.TODO(), &cloudwatchlogs.StartQueryInput{
+		LogGroupNames: groupNames,
+		QueryString:   &query,
+		StartTime:     &startTime,
+		EndTime:       &endTime,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return *resp.QueryId, nil
+}
+
+// GetQueryResults returns the results collected so far; they are complete
+// once the returned QueryResults is Done.
+func GetQueryResults(queryID *string) (*QueryResults, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+	resp, err := client.GetQueryResults(context.TODO(), &cloudwatchlogs.GetQueryResultsInput{
+		QueryId: queryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryResults{
+		Status:     resp.Status,
+		Rows:       resp.Results,
+		Statistics: resp.Statistics,
+	}, nil
+}
+
+func StopQuery(queryID *string) error {
+	if err := setupClient(); err != nil {
+		return err
+	}
+	_, err := client.StopQuery(context.TODO(), &cloudwatchlogs.StopQueryInput{
+		QueryId: queryID,
+	})
+	return err
+}
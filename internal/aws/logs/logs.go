@@ -0,0 +1,172 @@
+package logs
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/jaehong21/hibiscus/internal/aws"
+)
+
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs
+var client *cloudwatchlogs.Client
+
+// StreamLimit caps how many streams are listed for a group; busy groups can
+// hold far more streams than are useful to scroll through.
+const StreamLimit = 1000
+
+// DescribeLogGroups returns every log group in the region, following
+// NextToken across pages.
+func DescribeLogGroups() ([]types.LogGroup, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		groups []types.LogGroup
+		token  *string
+	)
+	for {
+		resp, err := client.DescribeLogGroups(context.TODO(), &cloudwatchlogs.DescribeLogGroupsInput{
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, resp.LogGroups...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return groups, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+// DescribeLogStreams returns the streams of a group with the most recently
+// written first, up to StreamLimit streams.
+func DescribeLogStreams(groupName *string) ([]types.LogStream, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	var (
+		streams    []types.LogStream
+		token      *string
+		descending = true
+	)
+	for len(streams) < StreamLimit {
+		resp, err := client.DescribeLogStreams(context.TODO(), &cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName: groupName,
+			OrderBy:      types.OrderByLastEventTime,
+			Descending:   &descending,
+			NextToken:    token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, resp.LogStreams...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		token = resp.NextToken
+	}
+	if len(streams) > StreamLimit {
+		streams = streams[:StreamLimit]
+	}
+
+	return streams, nil
+}
+
+// EventFilter selects the events FilterLogEvents returns.
+type EventFilter struct {
+	GroupName string
+	// StreamNames restricts the search to these streams; empty searches the
+	// whole group.
+	StreamNames []string
+	// Pattern is a CloudWatch Logs filter pattern; empty matches everything.
+	Pattern string
+	Start   time.Time
+	// Limit stops paging once this many events were collected.
+	Limit int
+	// NextToken resumes a search that stopped at Limit; the other fields
+	// must be unchanged.
+	NextToken *string
+}
+
+// FilterLogEvents returns the events matching filter that were written at or
+// after filter.Start, oldest first. Pages can come back empty while the
+// service is still searching, so NextToken is followed until it runs out or
+// filter.Limit events were collected. In the latter case the returned token
+// resumes the search.
+func FilterLogEvents(filter EventFilter) ([]types.FilteredLogEvent, *string, error) {
+	if err := setupClient(); err != nil {
+		return nil, nil, err
+	}
+
+	start := filter.Start.UnixMilli()
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: &filter.GroupName,
+		StartTime:    &start,
+		NextToken:    filter.NextToken,
+	}
+	if len(filter.StreamNames) > 0 {
+		input.LogStreamNames = filter.StreamNames
+	}
+	if filter.Pattern != "" {
+		input.FilterPattern = &filter.Pattern
+	}
+
+	var (
+		events []types.FilteredLogEvent
+		token  *string
+	)
+	for filter.Limit <= 0 || len(events) < filter.Limit {
+		resp, err := client.FilterLogEvents(context.TODO(), input)
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, resp.Events...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			token = nil
+			break
+		}
+		token = resp.NextToken
+		input.NextToken = resp.NextToken
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return timestamp(events[i].Timestamp) < timestamp(events[j].Timestamp)
+	})
+	return events, token, nil
+}
+
+// Time converts a CloudWatch Logs timestamp (milliseconds since the epoch).
+func Time(millis *int64) *time.Time {
+	if millis == nil || *millis == 0 {
+		return nil
+	}
+	t := time.UnixMilli(*millis)
+	return &t
+}
+
+func timestamp(millis *int64) int64 {
+	if millis == nil {
+		return 0
+	}
+	return *millis
+}
+
+func setupClient() error {
+	if client != nil {
+		return nil
+	}
+
+	cfg, err := aws.GetAWSConfig(context.Background())
+	if err != nil {
+		return err
+	}
+
+	client = cloudwatchlogs.NewFromConfig(cfg)
+	return nil
+}
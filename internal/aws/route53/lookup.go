@@ -0,0 +1,176 @@
+package route53
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+const (
+	// RecordCacheTTL is how long the records of a hosted zone are reused by
+	// FindRecordsPointingTo before the zone is listed again.
+	RecordCacheTTL = 5 * time.Minute
+
+	// lookupWorkers bounds concurrent ListResourceRecordSets calls; Route53
+	// throttles each account to five requests per second.
+	lookupWorkers = 4
+)
+
+// RecordReference is a record set that resolves to a DNS name, either through
+// an alias target or a CNAME value.
+type RecordReference struct {
+	ZoneID   string
+	ZoneName string
+	Record   types.ResourceRecordSet
+	// Via is "alias" or "CNAME".
+	Via string
+}
+
+type cachedZone struct {
+	records  []types.ResourceRecordSet
+	loadedAt time.Time
+}
+
+// recordCache keeps the records of every scanned hosted zone so that looking
+// up several load balancers in a row lists each zone only once.
+var recordCache = struct {
+	sync.Mutex
+	zones       []types.HostedZone
+	zonesLoaded time.Time
+	records     map[string]cachedZone
+}{records: map[string]cachedZone{}}
+
+// NormalizeDNSName lowercases a DNS name and strips the trailing dot and the
+// "dualstack." prefix Route53 adds to ELB alias targets.
+func NormalizeDNSName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimSuffix(name, ".")
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+// FindRecordsPointingTo scans every hosted zone for alias targets and CNAME
+// values that resolve to dnsName. Zone records are cached for RecordCacheTTL
+// unless refresh is set. Zones that fail to list are skipped and reported in
+// the returned error alongside the references that were found.
+func FindRecordsPointingTo(dnsName string, refresh bool) ([]RecordReference, error) {
+	target := NormalizeDNSName(dnsName)
+	if target == "" {
+		return nil, fmt.Errorf("dns name is required")
+	}
+
+	recordCache.Lock()
+	defer recordCache.Unlock()
+
+	now := time.Now()
+	if refresh || recordCache.zones == nil || now.Sub(recordCache.zonesLoaded) > RecordCacheTTL {
+		zones, err := ListHostedZones()
+		if err != nil {
+			return nil, err
+		}
+		recordCache.zones = zones
+		recordCache.zonesLoaded = now
+	}
+
+	var stale []types.HostedZone
+	for _, zone := range recordCache.zones {
+		cached, ok := recordCache.records[zoneKey(zone.Id)]
+		if refresh || !ok || now.Sub(cached.loadedAt) > RecordCacheTTL {
+			stale = append(stale, zone)
+		}
+	}
+	errs := loadZoneRecords(stale, now)
+
+	var references []RecordReference
+	for _, zone := range recordCache.zones {
+		cached, ok := recordCache.records[zoneKey(zone.Id)]
+		if !ok {
+			continue
+		}
+		for _, record := range cached.records {
+			if via, ok := pointsTo(record, target); ok {
+				references = append(references, RecordReference{
+					ZoneID:   zoneKey(zone.Id),
+					ZoneName: strings.TrimSuffix(derefString(zone.Name), "."),
+					Record:   record,
+					Via:      via,
+				})
+			}
+		}
+	}
+
+	return references, errors.Join(errs...)
+}
+
+// loadZoneRecords lists the given zones concurrently and stores them in the
+// cache. The caller must hold the cache lock.
+func loadZoneRecords(zones []types.HostedZone, now time.Time) []error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		jobs = make(chan types.HostedZone)
+	)
+
+	for range min(lookupWorkers, len(zones)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for zone := range jobs {
+				records, err := ListRecords(zone.Id)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", strings.TrimSuffix(derefString(zone.Name), "."), err))
+				} else {
+					recordCache.records[zoneKey(zone.Id)] = cachedZone{records: records, loadedAt: now}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, zone := range zones {
+		jobs <- zone
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
+
+func pointsTo(record types.ResourceRecordSet, target string) (string, bool) {
+	if record.AliasTarget != nil && NormalizeDNSName(derefString(record.AliasTarget.DNSName)) == target {
+		return "alias", true
+	}
+	if record.Type == types.RRTypeCname {
+		for _, rr := range record.ResourceRecords {
+			if NormalizeDNSName(derefString(rr.Value)) == target {
+				return "CNAME", true
+			}
+		}
+	}
+	return "", false
+}
+
+// invalidateRecordCache drops a zone after its records changed so the next
+// lookup lists it again.
+func invalidateRecordCache(hostedZoneID *string) {
+	recordCache.Lock()
+	defer recordCache.Unlock()
+	delete(recordCache.records, zoneKey(hostedZoneID))
+}
+
+// zoneKey strips the "/hostedzone/" prefix so IDs from ListHostedZones and
+// user input compare equal.
+func zoneKey(id *string) string {
+	return strings.TrimPrefix(derefString(id), "/hostedzone/")
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
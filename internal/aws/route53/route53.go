@@ -104,12 +104,27 @@ func ListHostedZones() ([]types.HostedZone, error) {
 		return nil, err
 	}
 
-	zones, err := client.ListHostedZones(context.TODO(), &route53.ListHostedZonesInput{})
-	if err != nil {
-		return nil, err
+	var (
+		results []types.HostedZone
+		marker  *string
+	)
+
+	for {
+		resp, err := client.ListHostedZones(context.TODO(), &route53.ListHostedZonesInput{
+			Marker: marker,
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, resp.HostedZones...)
+
+		if !resp.IsTruncated || resp.NextMarker == nil {
+			break
+		}
+		marker = resp.NextMarker
 	}
 
-	return zones.HostedZones, nil
+	return results, nil
 }
 
 func ListRecords(hostedZoneID *string) ([]types.ResourceRecordSet, error) {
@@ -178,6 +193,9 @@ func UpsertRecord(hostedZoneID *string, record types.ResourceRecordSet) error {
 			},
 		},
 	})
+	if err == nil {
+		invalidateRecordCache(hostedZoneID)
+	}
 
 	return err
 }
@@ -198,6 +216,9 @@ func DeleteRecord(hostedZoneID *string, record types.ResourceRecordSet) error {
 			},
 		},
 	})
+	if err == nil {
+		invalidateRecordCache(hostedZoneID)
+	}
 
 	return err
 }
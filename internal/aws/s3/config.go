@@ -0,0 +1,296 @@
+package s3
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Bucket access levels shown in the bucket list.
+const (
+	// AccessPublic means the bucket policy or ACL grants public access and
+	// the public access block does not neutralise it.
+	AccessPublic = "public"
+	// AccessNotBlocked means nothing public was found but the public access
+	// block is not fully enabled, so a policy or ACL change could expose it.
+	AccessNotBlocked = "not blocked"
+	// AccessBlocked means all four public access block settings are on.
+	AccessBlocked = "blocked"
+	// AccessUnknown means the settings could not be read.
+	AccessUnknown = "unknown"
+)
+
+// Sections of a bucket configuration, used as keys of BucketConfig.Errors.
+const (
+	SectionPolicy            = "Bucket policy"
+	SectionPublicAccessBlock = "Public access block"
+	SectionACL               = "ACL"
+	SectionEncryption        = "Default encryption"
+	SectionLifecycle         = "Lifecycle rules"
+	SectionReplication       = "Replication"
+	SectionCORS              = "CORS"
+	SectionWebsite           = "Website hosting"
+	SectionObjectLock        = "Object lock"
+)
+
+// Grantee group URIs that make an ACL grant public.
+const (
+	allUsersURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// BucketConfig is the configuration of a bucket as shown in the detail pane.
+// Unset sections are nil or empty.
+type BucketConfig struct {
+	Region string
+	// Policy is the bucket policy document as returned by S3.
+	Policy string
+	// PolicyPublic is S3's own evaluation of whether the policy is public.
+	PolicyPublic      bool
+	PublicAccessBlock *types.PublicAccessBlockConfiguration
+	Owner             *types.Owner
+	Grants            []types.Grant
+	Encryption        []types.ServerSideEncryptionRule
+	Lifecycle         []types.LifecycleRule
+	Replication       *types.ReplicationConfiguration
+	CORS              []types.CORSRule
+	Website           *Website
+	ObjectLock        *types.ObjectLockConfiguration
+	// Errors holds the sections that could not be read, for example when the
+	// caller lacks the permission for one of them.
+	Errors map[string]error
+}
+
+// Website is the static website hosting configuration of a bucket.
+type Website struct {
+	IndexDocument         string
+	ErrorDocument         string
+	RedirectAllRequestsTo *types.RedirectAllRequestsTo
+	RoutingRules          int
+}
+
+// Access classifies the bucket as AccessPublic, AccessNotBlocked,
+// AccessBlocked or AccessUnknown.
+func (c *BucketConfig) Access() string {
+	for _, section := range []string{SectionPolicy, SectionPublicAccessBlock, SectionACL} {
+		if c.Errors[section] != nil {
+			return AccessUnknown
+		}
+	}
+	return bucketAccess(c.PolicyPublic, c.PublicAccessBlock, c.Grants)
+}
+
+// DescribeBucketConfig reads every configuration section of a bucket. A
+// section that is not configured is left empty; any other failure is
+// recorded in Errors so the remaining sections are still shown.
+func DescribeBucketConfig(bucket *string) (*BucketConfig, error) {
+	region, err := GetBucketRegion(bucket)
+	if err != nil {
+		return nil, err
+	}
+	regional, err := regionalClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &BucketConfig{Region: region, Errors: map[string]error{}}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	// Each section is fetched concurrently; fetch returns the error of the
+	// section, which is ignored when it only says "not configured".
+	run := func(section string, notFound string, fetch func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fetch(); err != nil && (notFound == "" || !isErrorCode(err, notFound)) {
+				mu.Lock()
+				config.Errors[section] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run(SectionPolicy, "NoSuchBucketPolicy", func() error {
+		policy, err := regional.GetBucketPolicy(context.TODO(), &s3.GetBucketPolicyInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		status, err := regional.GetBucketPolicyStatus(context.TODO(), &s3.GetBucketPolicyStatusInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.Policy = derefString(policy.Policy)
+		config.PolicyPublic = status.PolicyStatus != nil && derefBool(status.PolicyStatus.IsPublic)
+		return nil
+	})
+	run(SectionPublicAccessBlock, "NoSuchPublicAccessBlockConfiguration", func() error {
+		resp, err := regional.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.PublicAccessBlock = resp.PublicAccessBlockConfiguration
+		return nil
+	})
+	run(SectionACL, "", func() error {
+		resp, err := regional.GetBucketAcl(context.TODO(), &s3.GetBucketAclInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.Owner = resp.Owner
+		config.Grants = resp.Grants
+		return nil
+	})
+	run(SectionEncryption, "ServerSideEncryptionConfigurationNotFoundError", func() error {
+		resp, err := regional.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		if resp.ServerSideEncryptionConfiguration != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			config.Encryption = resp.ServerSideEncryptionConfiguration.Rules
+		}
+		return nil
+	})
+	run(SectionLifecycle, "NoSuchLifecycleConfiguration", func() error {
+		resp, err := regional.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.Lifecycle = resp.Rules
+		return nil
+	})
+	run(SectionReplication, "ReplicationConfigurationNotFoundError", func() error {
+		resp, err := regional.GetBucketReplication(context.TODO(), &s3.GetBucketReplicationInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.Replication = resp.ReplicationConfiguration
+		return nil
+	})
+	run(SectionCORS, "NoSuchCORSConfiguration", func() error {
+		resp, err := regional.GetBucketCors(context.TODO(), &s3.GetBucketCorsInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.CORS = resp.CORSRules
+		return nil
+	})
+	run(SectionWebsite, "NoSuchWebsiteConfiguration", func() error {
+		resp, err := regional.GetBucketWebsite(context.TODO(), &s3.GetBucketWebsiteInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		website := &Website{
+			RedirectAllRequestsTo: resp.RedirectAllRequestsTo,
+			RoutingRules:          len(resp.RoutingRules),
+		}
+		if resp.IndexDocument != nil {
+			website.IndexDocument = derefString(resp.IndexDocument.Suffix)
+		}
+		if resp.ErrorDocument != nil {
+			website.ErrorDocument = derefString(resp.ErrorDocument.Key)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.Website = website
+		return nil
+	})
+	run(SectionObjectLock, "ObjectLockConfigurationNotFoundError", func() error {
+		resp, err := regional.GetObjectLockConfiguration(context.TODO(), &s3.GetObjectLockConfigurationInput{Bucket: bucket})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		config.ObjectLock = resp.ObjectLockConfiguration
+		return nil
+	})
+
+	wg.Wait()
+	return config, nil
+}
+
+// describeAccess reads only the settings that decide whether a bucket is
+// public, for the bucket list.
+func describeAccess(regional *s3.Client, bucket *string) string {
+	var policyPublic bool
+	status, err := regional.GetBucketPolicyStatus(context.TODO(), &s3.GetBucketPolicyStatusInput{Bucket: bucket})
+	switch {
+	case err == nil:
+		policyPublic = status.PolicyStatus != nil && derefBool(status.PolicyStatus.IsPublic)
+	case !isErrorCode(err, "NoSuchBucketPolicy"):
+		return AccessUnknown
+	}
+
+	var block *types.PublicAccessBlockConfiguration
+	resp, err := regional.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{Bucket: bucket})
+	switch {
+	case err == nil:
+		block = resp.PublicAccessBlockConfiguration
+	case !isErrorCode(err, "NoSuchPublicAccessBlockConfiguration"):
+		return AccessUnknown
+	}
+
+	acl, err := regional.GetBucketAcl(context.TODO(), &s3.GetBucketAclInput{Bucket: bucket})
+	if err != nil {
+		return AccessUnknown
+	}
+	return bucketAccess(policyPublic, block, acl.Grants)
+}
+
+// bucketAccess combines the policy status, public access block and ACL. The
+// account-level public access block is not taken into account.
+func bucketAccess(policyPublic bool, block *types.PublicAccessBlockConfiguration, grants []types.Grant) string {
+	var blockPolicy, ignoreACLs, fullyBlocked bool
+	if block != nil {
+		blockPolicy = derefBool(block.RestrictPublicBuckets)
+		ignoreACLs = derefBool(block.IgnorePublicAcls)
+		fullyBlocked = blockPolicy && ignoreACLs && derefBool(block.BlockPublicAcls) && derefBool(block.BlockPublicPolicy)
+	}
+
+	if policyPublic && !blockPolicy {
+		return AccessPublic
+	}
+	if !ignoreACLs {
+		for _, grant := range grants {
+			if IsPublicGrant(grant) {
+				return AccessPublic
+			}
+		}
+	}
+	if fullyBlocked {
+		return AccessBlocked
+	}
+	return AccessNotBlocked
+}
+
+// IsPublicGrant reports whether an ACL grant is to everyone or to every
+// authenticated AWS user.
+func IsPublicGrant(grant types.Grant) bool {
+	if grant.Grantee == nil || grant.Grantee.URI == nil {
+		return false
+	}
+	uri := *grant.Grantee.URI
+	return uri == allUsersURI || uri == authenticatedUsersURI
+}
+
+func derefBool(value *bool) bool {
+	return value != nil && *value
+}
@@ -0,0 +1,360 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// MultipartPartSize is the part size used for uploads; files larger than
+	// one part are uploaded with a multipart upload.
+	MultipartPartSize = 16 * 1024 * 1024
+
+	// deleteBatchSize is the most keys DeleteObjects accepts per call.
+	deleteBatchSize = 1000
+)
+
+// Progress reports transferred and total bytes of a download or upload.
+type Progress func(done, total int64)
+
+// ObjectPreview is the beginning of an object fetched for display.
+type ObjectPreview struct {
+	Body        []byte
+	ContentType string
+	Size        int64
+	// Truncated is set when the object is larger than the requested limit.
+	Truncated bool
+}
+
+// GetObjectPreview fetches at most limit bytes from the start of an object,
+// or of one of its versions when versionID is set.
+func GetObjectPreview(bucket, key, versionID *string, limit int64) (*ObjectPreview, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := regional.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket:    bucket,
+		Key:       key,
+		VersionId: versionID,
+		Range:     stringPtr(fmt.Sprintf("bytes=0-%d", limit-1)),
+	})
+	if err != nil {
+		// Empty objects cannot satisfy a byte range.
+		if isErrorCode(err, "InvalidRange") {
+			return &ObjectPreview{}, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ObjectPreview{
+		Body:        body,
+		ContentType: derefString(resp.ContentType),
+		Size:        int64(len(body)),
+	}
+	if total, ok := rangeTotal(derefString(resp.ContentRange)); ok {
+		preview.Size = total
+		preview.Truncated = total > int64(len(body))
+	}
+	return preview, nil
+}
+
+// DownloadObject writes an object to path. The file is written next to its
+// destination first and renamed once complete.
+func DownloadObject(bucket, key *string, path string, progress Progress) error {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return err
+	}
+
+	resp, err := regional.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: bucket,
+		Key:    key,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	partial := path + ".part"
+	file, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+
+	total := derefInt64(resp.ContentLength)
+	reader := &progressReader{reader: resp.Body, total: total, progress: progress}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(partial)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(partial)
+		return err
+	}
+	return os.Rename(partial, path)
+}
+
+// UploadFile uploads a local file to key. Files larger than MultipartPartSize
+// are sent as a multipart upload, which is aborted if any part fails.
+func UploadFile(bucket *string, key, path string, progress Progress) error {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	total := info.Size()
+
+	if total <= MultipartPartSize {
+		_, err := regional.PutObject(context.TODO(), &s3.PutObjectInput{
+			Bucket:        bucket,
+			Key:           &key,
+			Body:          &progressReader{reader: file, seeker: file, total: total, progress: progress},
+			ContentLength: &total,
+		})
+		return err
+	}
+
+	return uploadMultipart(regional, bucket, key, file, total, progress)
+}
+
+func uploadMultipart(regional *s3.Client, bucket *string, key string, file *os.File, total int64, progress Progress) error {
+	created, err := regional.CreateMultipartUpload(context.TODO(), &s3.CreateMultipartUploadInput{
+		Bucket: bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		parts []types.CompletedPart
+		sent  int64
+	)
+	for number := int32(1); sent < total; number++ {
+		size := min(int64(MultipartPartSize), total-sent)
+		section := io.NewSectionReader(file, sent, size)
+		offset := sent
+		reader := &progressReader{reader: section, seeker: section, total: total, progress: func(done, total int64) {
+			if progress != nil {
+				progress(offset+done, total)
+			}
+		}}
+
+		partNumber := number
+		resp, err := regional.UploadPart(context.TODO(), &s3.UploadPartInput{
+			Bucket:        bucket,
+			Key:           &key,
+			UploadId:      created.UploadId,
+			PartNumber:    &partNumber,
+			Body:          reader,
+			ContentLength: &size,
+		})
+		if err != nil {
+			abortErr := abortMultipart(regional, bucket, key, created.UploadId)
+			return errors.Join(fmt.Errorf("upload part %d: %w", number, err), abortErr)
+		}
+		parts = append(parts, types.CompletedPart{ETag: resp.ETag, PartNumber: &partNumber})
+		sent += size
+	}
+
+	_, err = regional.CompleteMultipartUpload(context.TODO(), &s3.CompleteMultipartUploadInput{
+		Bucket:          bucket,
+		Key:             &key,
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return errors.Join(err, abortMultipart(regional, bucket, key, created.UploadId))
+	}
+	return nil
+}
+
+func abortMultipart(regional *s3.Client, bucket *string, key string, uploadID *string) error {
+	_, err := regional.AbortMultipartUpload(context.TODO(), &s3.AbortMultipartUploadInput{
+		Bucket:   bucket,
+		Key:      &key,
+		UploadId: uploadID,
+	})
+	if err != nil {
+		return fmt.Errorf("abort multipart upload: %w", err)
+	}
+	return nil
+}
+
+// PresignGetObject returns a URL that downloads the object until it expires.
+func PresignGetObject(bucket, key *string, expiry time.Duration) (string, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return "", err
+	}
+
+	presigned, err := s3.NewPresignClient(regional).PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: bucket,
+		Key:    key,
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+	return presigned.URL, nil
+}
+
+// ListKeys returns every key below prefix, across all folder levels.
+func ListKeys(bucket, prefix *string) ([]string, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		keys  []string
+		token *string
+	)
+	for {
+		resp, err := regional.ListObjectsV2(context.TODO(), &s3.ListObjectsV2Input{
+			Bucket:            bucket,
+			Prefix:            prefix,
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Contents {
+			if object.Key != nil {
+				keys = append(keys, *object.Key)
+			}
+		}
+		if resp.IsTruncated == nil || !*resp.IsTruncated || resp.NextContinuationToken == nil {
+			return keys, nil
+		}
+		token = resp.NextContinuationToken
+	}
+}
+
+// DeleteObjects deletes the given keys in batches and returns how many were
+// removed. Keys S3 refuses to delete are reported in the error.
+func DeleteObjects(bucket *string, keys []string) (int, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted int
+		errs    []error
+	)
+	for start := 0; start < len(keys); start += deleteBatchSize {
+		batch := keys[start:min(start+deleteBatchSize, len(keys))]
+		objects := make([]types.ObjectIdentifier, 0, len(batch))
+		for _, key := range batch {
+			objects = append(objects, types.ObjectIdentifier{Key: stringPtr(key)})
+		}
+
+		resp, err := regional.DeleteObjects(context.TODO(), &s3.DeleteObjectsInput{
+			Bucket: bucket,
+			Delete: &types.Delete{Objects: objects, Quiet: boolPtr(true)},
+		})
+		if err != nil {
+			return deleted, errors.Join(append(errs, err)...)
+		}
+		deleted += len(batch) - len(resp.Errors)
+		for _, failure := range resp.Errors {
+			errs = append(errs, fmt.Errorf("%s: %s", derefString(failure.Key), derefString(failure.Message)))
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// progressReader counts bytes read and reports them. When the underlying
+// reader is seekable the counter follows seeks, so SDK retries that rewind
+// the body do not overstate progress.
+type progressReader struct {
+	reader   io.Reader
+	seeker   io.Seeker
+	done     int64
+	total    int64
+	progress Progress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.done += int64(n)
+	if r.progress != nil && n > 0 {
+		r.progress(r.done, r.total)
+	}
+	return n, err
+}
+
+func (r *progressReader) Seek(offset int64, whence int) (int64, error) {
+	if r.seeker == nil {
+		return 0, errors.New("progress reader is not seekable")
+	}
+	position, err := r.seeker.Seek(offset, whence)
+	if err == nil {
+		r.done = position
+	}
+	return position, err
+}
+
+// rangeTotal parses the object size out of a Content-Range header such as
+// "bytes 0-99/1234".
+func rangeTotal(contentRange string) (int64, bool) {
+	_, total, ok := strings.Cut(contentRange, "/")
+	if !ok || total == "*" {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(total, 10, 64)
+	return value, err == nil
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func derefInt64(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,351 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const testBucket = "bucket"
+
+// fakeS3 is a minimal path-style S3 stand-in covering the calls made by
+// objects.go. failPart makes UploadPart fail for that part number.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	aborted  []string
+	failPart int
+	uploadID int
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	t.Helper()
+
+	fake := &fakeS3{objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	t.Setenv(EndpointEnv, server.URL)
+	client = newClient(awssdk.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+	regionMu.Lock()
+	regionalClients = map[string]*s3.Client{}
+	bucketRegions = map[string]string{testBucket: "us-east-1"}
+	regionMu.Unlock()
+	t.Cleanup(func() { client = nil })
+
+	return fake
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != testBucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("delete"):
+		f.deleteObjects(w, body)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.uploadID++
+		id := fmt.Sprintf("upload-%d", f.uploadID)
+		f.uploads[id] = map[int][]byte{}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", bucket, key, id)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if number == f.failPart {
+			writeError(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		parts[number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		var joined []byte
+		for number := 1; number <= len(parts); number++ {
+			joined = append(joined, parts[number]...)
+		}
+		f.objects[key] = joined
+		delete(f.uploads, query.Get("uploadId"))
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>"done"</ETag></CompleteMultipartUploadResult>`, bucket, key)
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		f.aborted = append(f.aborted, query.Get("uploadId"))
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet:
+		f.getObject(w, r, key)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeS3) getObject(w http.ResponseWriter, r *http.Request, key string) {
+	data, ok := f.objects[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+
+	var start, end int
+	if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err != nil {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data)
+		return
+	}
+	if start >= len(data) {
+		writeError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+		return
+	}
+	end = min(end, len(data)-1)
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+	w.Header().Set("Content-Length", strconv.Itoa(end-start+1))
+	w.WriteHeader(http.StatusPartialContent)
+	w.Write(data[start : end+1])
+}
+
+func (f *fakeS3) deleteObjects(w http.ResponseWriter, body []byte) {
+	var req struct {
+		Objects []struct {
+			Key string `xml:"Key"`
+		} `xml:"Object"`
+	}
+	if err := xml.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	var result bytes.Buffer
+	result.WriteString("<DeleteResult>")
+	for _, object := range req.Objects {
+		if strings.HasPrefix(object.Key, "locked/") {
+			fmt.Fprintf(&result, "<Error><Key>%s</Key><Code>AccessDenied</Code><Message>Access Denied</Message></Error>", object.Key)
+			continue
+		}
+		delete(f.objects, object.Key)
+	}
+	result.WriteString("</DeleteResult>")
+	w.Write(result.Bytes())
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func TestGetObjectPreview(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.objects["short.txt"] = []byte("hello")
+	fake.objects["long.txt"] = []byte("0123456789")
+	fake.objects["empty.txt"] = nil
+
+	bucket := testBucket
+	tests := []struct {
+		key       string
+		limit     int64
+		body      string
+		size      int64
+		truncated bool
+	}{
+		{key: "short.txt", limit: 512, body: "hello", size: 5},
+		{key: "long.txt", limit: 4, body: "0123", size: 10, truncated: true},
+		{key: "empty.txt", limit: 512, body: "", size: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			key := tt.key
+			preview, err := GetObjectPreview(&bucket, &key, nil, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(preview.Body) != tt.body || preview.Size != tt.size || preview.Truncated != tt.truncated {
+				t.Errorf("got body %q size %d truncated %v, want %q %d %v",
+					preview.Body, preview.Size, preview.Truncated, tt.body, tt.size, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestDownloadObject(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.objects["dir/file.txt"] = []byte("downloaded content")
+
+	bucket, key := testBucket, "dir/file.txt"
+	path := filepath.Join(t.TempDir(), "nested", "file.txt")
+	var reported int64
+	if err := DownloadObject(&bucket, &key, path, func(done, total int64) { reported = done }); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "downloaded content" {
+		t.Errorf("downloaded %q", data)
+	}
+	if reported != int64(len(data)) {
+		t.Errorf("progress reported %d bytes, want %d", reported, len(data))
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file left behind: %v", err)
+	}
+}
+
+func TestDownloadObjectMissing(t *testing.T) {
+	newFakeS3(t)
+
+	bucket, key := testBucket, "missing.txt"
+	path := filepath.Join(t.TempDir(), "missing.txt")
+	if err := DownloadObject(&bucket, &key, path, nil); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file created for a failed download: %v", err)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	fake := newFakeS3(t)
+
+	path := filepath.Join(t.TempDir(), "small.txt")
+	if err := os.WriteFile(path, []byte("small upload"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	bucket := testBucket
+	if err := UploadFile(&bucket, "uploads/small.txt", path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(fake.objects["uploads/small.txt"]); got != "small upload" {
+		t.Errorf("stored %q", got)
+	}
+}
+
+func TestUploadFileMultipart(t *testing.T) {
+	fake := newFakeS3(t)
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), (MultipartPartSize+MultipartPartSize/2)/16)
+	path := filepath.Join(t.TempDir(), "large.bin")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := testBucket
+	var reported int64
+	if err := UploadFile(&bucket, "large.bin", path, func(done, total int64) { reported = done }); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(fake.objects["large.bin"], data) {
+		t.Errorf("stored %d bytes, want %d", len(fake.objects["large.bin"]), len(data))
+	}
+	if reported != int64(len(data)) {
+		t.Errorf("progress reported %d bytes, want %d", reported, len(data))
+	}
+	if len(fake.uploads) != 0 || len(fake.aborted) != 0 {
+		t.Errorf("uploads left %d, aborted %v", len(fake.uploads), fake.aborted)
+	}
+}
+
+func TestUploadFileMultipartAbortsOnFailure(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.failPart = 2
+
+	data := bytes.Repeat([]byte{'x'}, MultipartPartSize+1)
+	path := filepath.Join(t.TempDir(), "large.bin")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := testBucket
+	err := UploadFile(&bucket, "large.bin", path, nil)
+	if err == nil || !strings.Contains(err.Error(), "upload part 2") {
+		t.Fatalf("expected the part 2 failure, got %v", err)
+	}
+	if len(fake.aborted) != 1 {
+		t.Errorf("aborted uploads %v, want one", fake.aborted)
+	}
+	if _, ok := fake.objects["large.bin"]; ok {
+		t.Error("object stored despite the failed part")
+	}
+}
+
+func TestPresignGetObject(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.objects["shared.txt"] = []byte("shared")
+
+	bucket, key := testBucket, "shared.txt"
+	url, err := PresignGetObject(&bucket, &key, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(url, "/bucket/shared.txt?") || !strings.Contains(url, "X-Amz-Expires=900") {
+		t.Errorf("unexpected presigned URL %s", url)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "shared" {
+		t.Errorf("GET presigned URL: %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestDeleteObjects(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.objects["a.txt"] = []byte("a")
+	fake.objects["b.txt"] = []byte("b")
+	fake.objects["locked/c.txt"] = []byte("c")
+
+	bucket := testBucket
+	deleted, err := DeleteObjects(&bucket, []string{"a.txt", "b.txt", "locked/c.txt"})
+	if deleted != 2 {
+		t.Errorf("deleted %d, want 2", deleted)
+	}
+	if err == nil || !strings.Contains(err.Error(), "locked/c.txt") {
+		t.Errorf("expected the refused key in the error, got %v", err)
+	}
+	if _, ok := fake.objects["a.txt"]; ok {
+		t.Error("a.txt was not deleted")
+	}
+	if _, ok := fake.objects["locked/c.txt"]; !ok {
+		t.Error("locked/c.txt was deleted")
+	}
+}
@@ -2,14 +2,61 @@ package s3
 
 import (
 	"context"
+	"errors"
+	"os"
+	"sync"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/jaehong21/hibiscus/internal/aws"
 )
 
+// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/s3
 var client *s3.Client
 
+// Bucket-level calls must be sent to the bucket's own region, so a client is
+// kept per region and the region of every bucket is remembered.
+var (
+	regionMu        sync.Mutex
+	regionalClients = map[string]*s3.Client{}
+	bucketRegions   = map[string]string{}
+)
+
+// EndpointEnv names the environment variable that overrides the S3 endpoint.
+const EndpointEnv = "HIBISCUS_S3_ENDPOINT"
+
+// Delimiter separates "folders" in object keys.
+const Delimiter = "/"
+
+// maxKeysPerListing caps how many keys and prefixes ListObjects collects
+// before handing a continuation token back to the caller.
+const maxKeysPerListing = 5000
+
+// summaryWorkers bounds how many buckets are described concurrently.
+const summaryWorkers = 8
+
+// BucketSummary holds the settings shown next to each bucket in the list.
+type BucketSummary struct {
+	Region string
+	// Versioning is Enabled, Suspended or Disabled for buckets that never
+	// had versioning turned on.
+	Versioning string
+	// Encryption is the default encryption algorithm, or "none".
+	Encryption string
+	// Access flags potentially public buckets, see AccessPublic.
+	Access string
+}
+
+// ObjectListing is one folder level of a bucket.
+type ObjectListing struct {
+	Prefixes []string
+	Objects  []types.Object
+	// NextToken is set when more keys remain under the prefix.
+	NextToken *string
+}
+
 func DescribeBuckets() ([]types.Bucket, error) {
 	if err := setupClient(); err != nil {
 		return nil, err
@@ -22,6 +69,186 @@ func DescribeBuckets() ([]types.Bucket, error) {
 	return buckets.Buckets, nil
 }
 
+// GetBucketRegion returns the region a bucket lives in. Results are cached.
+func GetBucketRegion(bucket *string) (string, error) {
+	if err := setupClient(); err != nil {
+		return "", err
+	}
+
+	regionMu.Lock()
+	region, ok := bucketRegions[*bucket]
+	regionMu.Unlock()
+	if ok {
+		return region, nil
+	}
+
+	resp, err := client.GetBucketLocation(context.TODO(), &s3.GetBucketLocationInput{
+		Bucket: bucket,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLocation.html
+	switch resp.LocationConstraint {
+	case "":
+		region = "us-east-1"
+	case types.BucketLocationConstraintEu:
+		region = "eu-west-1"
+	default:
+		region = string(resp.LocationConstraint)
+	}
+
+	regionMu.Lock()
+	bucketRegions[*bucket] = region
+	regionMu.Unlock()
+	return region, nil
+}
+
+// DescribeBucket gathers the region, versioning status, default encryption
+// and public access level of a bucket.
+func DescribeBucket(bucket *string) (BucketSummary, error) {
+	region, err := GetBucketRegion(bucket)
+	if err != nil {
+		return BucketSummary{}, err
+	}
+	regional, err := regionalClient(region)
+	if err != nil {
+		return BucketSummary{}, err
+	}
+	summary := BucketSummary{Region: region, Versioning: "Disabled", Encryption: "none"}
+
+	versioning, err := regional.GetBucketVersioning(context.TODO(), &s3.GetBucketVersioningInput{
+		Bucket: bucket,
+	})
+	if err != nil {
+		return BucketSummary{}, err
+	}
+	if versioning.Status != "" {
+		summary.Versioning = string(versioning.Status)
+	}
+
+	encryption, err := regional.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{
+		Bucket: bucket,
+	})
+	if err != nil && !isErrorCode(err, "ServerSideEncryptionConfigurationNotFoundError") {
+		return BucketSummary{}, err
+	}
+	if encryption != nil && encryption.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault != nil {
+				summary.Encryption = string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+				break
+			}
+		}
+	}
+
+	summary.Access = describeAccess(regional, bucket)
+	return summary, nil
+}
+
+// DescribeBucketSummaries runs DescribeBucket for every bucket with a bounded
+// worker pool. onResult is invoked from the worker goroutines as each bucket
+// finishes; a per-bucket error is reported through it rather than aborting.
+func DescribeBucketSummaries(names []string, onResult func(name string, summary BucketSummary, err error)) {
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+
+	for range summaryWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				bucket := name
+				summary, err := DescribeBucket(&bucket)
+				onResult(bucket, summary, err)
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// ListObjects lists the sub-prefixes and objects directly below prefix,
+// following ListObjectsV2 pages until maxKeysPerListing entries are
+// collected. Pass the returned NextToken back to continue the listing.
+func ListObjects(bucket, prefix, continuationToken *string) (*ObjectListing, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &ObjectListing{}
+	token := continuationToken
+	for {
+		resp, err := regional.ListObjectsV2(context.TODO(), &s3.ListObjectsV2Input{
+			Bucket:            bucket,
+			Prefix:            prefix,
+			Delimiter:         stringPtr(Delimiter),
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, common := range resp.CommonPrefixes {
+			if common.Prefix != nil {
+				listing.Prefixes = append(listing.Prefixes, *common.Prefix)
+			}
+		}
+		listing.Objects = append(listing.Objects, resp.Contents...)
+
+		if resp.IsTruncated == nil || !*resp.IsTruncated || resp.NextContinuationToken == nil {
+			return listing, nil
+		}
+		token = resp.NextContinuationToken
+		if len(listing.Prefixes)+len(listing.Objects) >= maxKeysPerListing {
+			listing.NextToken = token
+			return listing, nil
+		}
+	}
+}
+
+// bucketClient returns a client for the region the bucket lives in.
+func bucketClient(bucket *string) (*s3.Client, error) {
+	region, err := GetBucketRegion(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return regionalClient(region)
+}
+
+func regionalClient(region string) (*s3.Client, error) {
+	if err := setupClient(); err != nil {
+		return nil, err
+	}
+
+	regionMu.Lock()
+	defer regionMu.Unlock()
+	if regional, ok := regionalClients[region]; ok {
+		return regional, nil
+	}
+	regional := s3.New(client.Options(), func(o *s3.Options) {
+		o.Region = region
+	})
+	regionalClients[region] = regional
+	return regional, nil
+}
+
+// isErrorCode reports whether err is an S3 API error with the given code.
+// Most S3 "not configured" responses are not modeled as typed errors.
+func isErrorCode(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
 func setupClient() error {
 	if client != nil {
 		return nil
@@ -30,6 +257,17 @@ func setupClient() error {
 	if err != nil {
 		return err
 	}
-	client = s3.NewFromConfig(cfg)
+	client = newClient(cfg)
 	return nil
 }
+
+func newClient(cfg awssdk.Config) *s3.Client {
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// Point every client at an S3-compatible endpoint (e.g. MinIO or
+		// LocalStack) for local testing.
+		if endpoint := os.Getenv(EndpointEnv); endpoint != "" {
+			o.BaseEndpoint = &endpoint
+			o.UsePathStyle = true
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// maxCopySize is the largest object CopyObject can copy in one request.
+const maxCopySize = 5 * 1024 * 1024 * 1024
+
+// ObjectVersion is a version or delete marker of a key.
+type ObjectVersion struct {
+	Key            string
+	VersionID      string
+	IsLatest       bool
+	IsDeleteMarker bool
+	LastModified   *time.Time
+	Size           *int64
+	ETag           string
+	StorageClass   string
+}
+
+// VersionMarker is where a version listing continues.
+type VersionMarker struct {
+	Key       *string
+	VersionID *string
+}
+
+// VersionListing is one folder level of a bucket with every version.
+type VersionListing struct {
+	Prefixes []string
+	// Versions are sorted by key, newest first within a key.
+	Versions []ObjectVersion
+	// NextMarker is set when more versions remain under the prefix.
+	NextMarker *VersionMarker
+}
+
+// ListObjectVersions lists the sub-prefixes directly below prefix and every
+// version and delete marker of the keys there, following pages until
+// maxKeysPerListing entries are collected. Pass the returned NextMarker back
+// to continue the listing.
+func ListObjectVersions(bucket, prefix *string, marker *VersionMarker) (*VersionListing, error) {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &VersionListing{}
+	input := &s3.ListObjectVersionsInput{
+		Bucket:    bucket,
+		Prefix:    prefix,
+		Delimiter: stringPtr(Delimiter),
+	}
+	if marker != nil {
+		input.KeyMarker = marker.Key
+		input.VersionIdMarker = marker.VersionID
+	}
+
+	for {
+		resp, err := regional.ListObjectVersions(context.TODO(), input)
+		if err != nil {
+			return nil, err
+		}
+		for _, common := range resp.CommonPrefixes {
+			if common.Prefix != nil {
+				listing.Prefixes = append(listing.Prefixes, *common.Prefix)
+			}
+		}
+		for _, version := range resp.Versions {
+			listing.Versions = append(listing.Versions, ObjectVersion{
+				Key:          derefString(version.Key),
+				VersionID:    derefString(version.VersionId),
+				IsLatest:     version.IsLatest != nil && *version.IsLatest,
+				LastModified: version.LastModified,
+				Size:         version.Size,
+				ETag:         derefString(version.ETag),
+				StorageClass: string(version.StorageClass),
+			})
+		}
+		for _, deleteMarker := range resp.DeleteMarkers {
+			listing.Versions = append(listing.Versions, ObjectVersion{
+				Key:            derefString(deleteMarker.Key),
+				VersionID:      derefString(deleteMarker.VersionId),
+				IsLatest:       deleteMarker.IsLatest != nil && *deleteMarker.IsLatest,
+				IsDeleteMarker: true,
+				LastModified:   deleteMarker.LastModified,
+			})
+		}
+
+		if resp.IsTruncated == nil || !*resp.IsTruncated {
+			break
+		}
+		input.KeyMarker = resp.NextKeyMarker
+		input.VersionIdMarker = resp.NextVersionIdMarker
+		if len(listing.Prefixes)+len(listing.Versions) >= maxKeysPerListing {
+			listing.NextMarker = &VersionMarker{Key: resp.NextKeyMarker, VersionID: resp.NextVersionIdMarker}
+			break
+		}
+	}
+
+	// Versions and delete markers come back in separate lists.
+	sort.SliceStable(listing.Versions, func(i, j int) bool {
+		a, b := listing.Versions[i], listing.Versions[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.IsLatest != b.IsLatest {
+			return a.IsLatest
+		}
+		if a.LastModified == nil || b.LastModified == nil {
+			return b.LastModified == nil && a.LastModified != nil
+		}
+		return a.LastModified.After(*b.LastModified)
+	})
+	return listing, nil
+}
+
+// RestoreVersion makes a previous version the latest one by copying it over
+// the key. Its metadata is copied along and the old version stays in place.
+func RestoreVersion(bucket *string, version ObjectVersion) error {
+	if version.IsDeleteMarker {
+		return fmt.Errorf("%s: a delete marker cannot be restored, remove it instead", version.Key)
+	}
+	if version.Size != nil && *version.Size > maxCopySize {
+		return fmt.Errorf("%s: versions larger than 5 GiB cannot be restored with a single copy", version.Key)
+	}
+
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return err
+	}
+
+	source := (&url.URL{Path: *bucket + "/" + version.Key}).EscapedPath() +
+		"?versionId=" + url.QueryEscape(version.VersionID)
+	_, err = regional.CopyObject(context.TODO(), &s3.CopyObjectInput{
+		Bucket:            bucket,
+		Key:               &version.Key,
+		CopySource:        &source,
+		MetadataDirective: types.MetadataDirectiveCopy,
+	})
+	return err
+}
+
+// DeleteVersion permanently deletes one version of a key. Deleting a delete
+// marker that is the latest version makes the previous version current
+// again.
+func DeleteVersion(bucket *string, version ObjectVersion) error {
+	regional, err := bucketClient(bucket)
+	if err != nil {
+		return err
+	}
+
+	_, err = regional.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket:    bucket,
+		Key:       &version.Key,
+		VersionId: &version.VersionID,
+	})
+	return err
+}
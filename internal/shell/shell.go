@@ -0,0 +1,224 @@
+// Package shell builds and runs the external commands hibiscus hands the
+// terminal over to, such as SSM sessions and SSH.
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Target is the instance a session is opened to.
+type Target struct {
+	InstanceID string
+	Name       string
+	PrivateIP  string
+	PublicIP   string
+}
+
+// IP is the public address when the instance has one, otherwise the private
+// address.
+func (t Target) IP() string {
+	if t.PublicIP != "" {
+		return t.PublicIP
+	}
+	return t.PrivateIP
+}
+
+// AWSOptions selects the AWS CLI executable and the credentials it uses, so
+// the CLI talks to the same account and region as hibiscus.
+type AWSOptions struct {
+	// CLI is the aws executable; a path to a stub works as well.
+	CLI     string
+	Profile string
+	Region  string
+}
+
+// PortForward describes an SSM port-forwarding session. An empty RemoteHost
+// forwards to the instance itself.
+type PortForward struct {
+	RemoteHost string
+	RemotePort int
+	LocalPort  int
+}
+
+// SSMCommand returns the argv of "aws ssm start-session" for target, with a
+// port-forwarding document when forward is set.
+func SSMCommand(opts AWSOptions, target Target, forward *PortForward) ([]string, error) {
+	if target.InstanceID == "" {
+		return nil, errors.New("instance ID is required")
+	}
+	cli := opts.CLI
+	if cli == "" {
+		cli = "aws"
+	}
+
+	argv := []string{cli, "ssm", "start-session", "--target", target.InstanceID}
+	if forward != nil {
+		if err := validPort(forward.RemotePort); err != nil {
+			return nil, fmt.Errorf("remote port: %w", err)
+		}
+		if err := validPort(forward.LocalPort); err != nil {
+			return nil, fmt.Errorf("local port: %w", err)
+		}
+		parameters := fmt.Sprintf("portNumber=%d,localPortNumber=%d", forward.RemotePort, forward.LocalPort)
+		document := "AWS-StartPortForwardingSession"
+		if forward.RemoteHost != "" {
+			document = "AWS-StartPortForwardingSessionToRemoteHost"
+			parameters = fmt.Sprintf("host=%s,%s", forward.RemoteHost, parameters)
+		}
+		argv = append(argv, "--document-name", document, "--parameters", parameters)
+	}
+	if opts.Profile != "" {
+		argv = append(argv, "--profile", opts.Profile)
+	}
+	if opts.Region != "" {
+		argv = append(argv, "--region", opts.Region)
+	}
+	return argv, nil
+}
+
+// SSHCommand expands an SSH command template such as
+// "ssh -i ~/.ssh/key.pem ec2-user@{{ip}}". The template is split into
+// arguments before placeholders are substituted, so values never need
+// quoting. Supported placeholders are {{id}}, {{name}}, {{ip}},
+// {{private_ip}} and {{public_ip}}.
+func SSHCommand(template string, target Target) ([]string, error) {
+	fields, err := splitArgs(template)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("ssh command template is empty")
+	}
+
+	pairs := []string{
+		"{{id}}", target.InstanceID,
+		"{{name}}", target.Name,
+		"{{ip}}", target.IP(),
+		"{{private_ip}}", target.PrivateIP,
+		"{{public_ip}}", target.PublicIP,
+	}
+	// A single replacer substitutes in one pass, so a value that looks like
+	// a placeholder (e.g. a Name tag of "{{ip}}") is never expanded again.
+	replacer := strings.NewReplacer(pairs...)
+
+	argv := make([]string, 0, len(fields))
+	for _, field := range fields {
+		for idx := 0; idx < len(pairs); idx += 2 {
+			if strings.Contains(field, pairs[idx]) && pairs[idx+1] == "" {
+				return nil, fmt.Errorf("%s is not available for instance %s", pairs[idx], target.InstanceID)
+			}
+		}
+		// Only the template's own "~" is expanded, never one from a value.
+		argv = append(argv, replacer.Replace(expandHome(field)))
+	}
+	return argv, nil
+}
+
+// Run executes argv attached to the current terminal and waits for it to
+// exit. Interrupts are left to the command, so Ctrl+C ends a port-forwarding
+// session without killing hibiscus.
+func Run(argv []string) error {
+	if len(argv) == 0 {
+		return errors.New("no command to run")
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+
+	// A command stopped with Ctrl+C was ended on purpose.
+	select {
+	case <-signals:
+		return nil
+	default:
+		return err
+	}
+}
+
+// Quote renders argv for display, quoting arguments that contain spaces.
+func Quote(argv []string) string {
+	quoted := make([]string, 0, len(argv))
+	for _, arg := range argv {
+		if arg == "" || strings.ContainsFunc(arg, unicode.IsSpace) || strings.ContainsAny(arg, `"'`) {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// splitArgs splits a command line on whitespace, honouring single and double
+// quotes and backslash escapes.
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func expandHome(arg string) string {
+	if arg == "~" || strings.HasPrefix(arg, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + strings.TrimPrefix(arg, "~")
+		}
+	}
+	return arg
+}
+
+func validPort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%d is not a valid port", port)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "ssh host", want: []string{"ssh", "host"}},
+		{line: "  ssh   -p 22\thost  ", want: []string{"ssh", "-p", "22", "host"}},
+		{line: `ssh -o "ProxyCommand=ssh -W %h:%p bastion" host`, want: []string{"ssh", "-o", "ProxyCommand=ssh -W %h:%p bastion", "host"}},
+		{line: `ssh -i '~/my keys/id' host`, want: []string{"ssh", "-i", "~/my keys/id", "host"}},
+		{line: `echo 'it\'s'`, wantErr: true},
+		{line: `echo "say \"hi\""`, want: []string{"echo", `say "hi"`}},
+		{line: `echo a\ b`, want: []string{"echo", "a b"}},
+		{line: `echo ""`, want: []string{"echo", ""}},
+		{line: `ssh "host`, wantErr: true},
+		{line: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := splitArgs(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSHCommand(t *testing.T) {
+	t.Setenv("HOME", "/home/tester")
+
+	target := Target{InstanceID: "i-0123", Name: "web-1", PrivateIP: "10.0.0.5", PublicIP: "54.1.2.3"}
+	tests := []struct {
+		name     string
+		template string
+		target   Target
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "default",
+			template: "ssh {{ip}}",
+			target:   target,
+			want:     []string{"ssh", "54.1.2.3"},
+		},
+		{
+			name:     "private ip fallback",
+			template: "ssh {{ip}}",
+			target:   Target{InstanceID: "i-0123", PrivateIP: "10.0.0.5"},
+			want:     []string{"ssh", "10.0.0.5"},
+		},
+		{
+			name:     "every placeholder and home expansion",
+			template: `ssh -i ~/.ssh/key.pem -o "HostKeyAlias={{name}} {{id}}" ec2-user@{{private_ip}} echo {{public_ip}}`,
+			target:   target,
+			want:     []string{"ssh", "-i", "/home/tester/.ssh/key.pem", "-o", "HostKeyAlias=web-1 i-0123", "ec2-user@10.0.0.5", "echo", "54.1.2.3"},
+		},
+		{
+			name:     "bare home",
+			template: "ls ~",
+			target:   target,
+			want:     []string{"ls", "/home/tester"},
+		},
+		{
+			name:     "values are not expanded again",
+			template: "ssh -l {{name}} {{ip}}",
+			target:   Target{InstanceID: "i-0123", Name: "{{ip}}", PublicIP: "54.1.2.3"},
+			want:     []string{"ssh", "-l", "{{ip}}", "54.1.2.3"},
+		},
+		{
+			name:     "home in a value is kept",
+			template: "echo {{name}}",
+			target:   Target{InstanceID: "i-0123", Name: "~/tag"},
+			want:     []string{"echo", "~/tag"},
+		},
+		{
+			name:     "values with spaces stay one argument",
+			template: "echo {{name}}",
+			target:   Target{InstanceID: "i-0123", Name: "my web; rm -rf /"},
+			want:     []string{"echo", "my web; rm -rf /"},
+		},
+		{
+			name:     "missing value",
+			template: "ssh {{public_ip}}",
+			target:   Target{InstanceID: "i-0123", PrivateIP: "10.0.0.5"},
+			wantErr:  "{{public_ip}} is not available",
+		},
+		{
+			name:     "empty template",
+			template: "   ",
+			target:   target,
+			wantErr:  "empty",
+		},
+		{
+			name:     "unterminated quote",
+			template: `ssh "{{ip}}`,
+			target:   target,
+			wantErr:  "unterminated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SSHCommand(tt.template, tt.target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSMCommand(t *testing.T) {
+	target := Target{InstanceID: "i-0123"}
+	tests := []struct {
+		name    string
+		opts    AWSOptions
+		target  Target
+		forward *PortForward
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "session",
+			target: target,
+			want:   []string{"aws", "ssm", "start-session", "--target", "i-0123"},
+		},
+		{
+			name:   "profile and region",
+			opts:   AWSOptions{CLI: "/opt/aws", Profile: "prod", Region: "eu-west-1"},
+			target: target,
+			want:   []string{"/opt/aws", "ssm", "start-session", "--target", "i-0123", "--profile", "prod", "--region", "eu-west-1"},
+		},
+		{
+			name:    "forward to the instance",
+			target:  target,
+			forward: &PortForward{RemotePort: 80, LocalPort: 8080},
+			want: []string{"aws", "ssm", "start-session", "--target", "i-0123",
+				"--document-name", "AWS-StartPortForwardingSession", "--parameters", "portNumber=80,localPortNumber=8080"},
+		},
+		{
+			name:    "forward to a remote host",
+			target:  target,
+			forward: &PortForward{RemoteHost: "db.internal", RemotePort: 5432, LocalPort: 15432},
+			want: []string{"aws", "ssm", "start-session", "--target", "i-0123",
+				"--document-name", "AWS-StartPortForwardingSessionToRemoteHost", "--parameters", "host=db.internal,portNumber=5432,localPortNumber=15432"},
+		},
+		{
+			name:    "remote port zero",
+			target:  target,
+			forward: &PortForward{RemotePort: 0, LocalPort: 8080},
+			wantErr: "remote port",
+		},
+		{
+			name:    "local port too large",
+			target:  target,
+			forward: &PortForward{RemotePort: 80, LocalPort: 70000},
+			wantErr: "local port",
+		},
+		{
+			name:    "missing instance",
+			wantErr: "instance ID is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SSMCommand(tt.opts, tt.target, tt.forward)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	got := Quote([]string{"aws", "ssm", "--parameters", "host=a b", "", `it's`})
+	want := `aws ssm --parameters "host=a b" "" "it's"`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// TestRun runs an SSM command through a stub aws_cli script that records its
+// arguments.
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub script needs a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "args")
+	stub := filepath.Join(dir, "aws")
+	script := "#!/bin/sh\nfor arg in \"$@\"; do echo \"$arg\"; done > \"" + out + "\"\n"
+	if err := os.WriteFile(stub, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	argv, err := SSMCommand(AWSOptions{CLI: stub, Profile: "dev"}, Target{InstanceID: "i-0123"},
+		&PortForward{RemoteHost: "db", RemotePort: 5432, LocalPort: 15432})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Run(argv); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Split(strings.TrimSpace(string(recorded)), "\n")
+	if !reflect.DeepEqual(got, argv[1:]) {
+		t.Errorf("stub received %q, want %q", got, argv[1:])
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub script needs a POSIX shell")
+	}
+
+	stub := filepath.Join(t.TempDir(), "aws")
+	if err := os.WriteFile(stub, []byte("#!/bin/sh\nexit 3\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run([]string{stub, "ssm"}); err == nil {
+		t.Fatal("expected the exit status as an error")
+	}
+	if err := Run(nil); err == nil {
+		t.Fatal("expected an error for an empty command")
+	}
+}
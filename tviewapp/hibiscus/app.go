@@ -33,6 +33,15 @@ type App struct {
 	current  Service
 
 	palette *commandPalette
+
+	// history is the back stack of services left through Navigate; start is
+	// the deep link opened by Run.
+	history []string
+	start   *Location
+
+	// firingAlarms is the number of CloudWatch alarms in ALARM state, shown
+	// as a badge in the header.
+	firingAlarms int
 }
 
 // New wires the provided services into a single application instance.
@@ -65,9 +74,11 @@ func New(cfg *config.Config, factories []ServiceFactory) (*App, error) {
 	}
 
 	ctx := ServiceContext{
-		App:       tviewApp,
-		SetStatus: hib.setStatus,
-		SetError:  hib.setError,
+		App:             tviewApp,
+		SetStatus:       hib.setStatus,
+		SetError:        hib.setError,
+		Navigate:        hib.navigate,
+		SetFiringAlarms: hib.setFiringAlarms,
 	}
 
 	// Instantiate services in the provided order so the palette matches the
@@ -95,7 +106,11 @@ func New(cfg *config.Config, factories []ServiceFactory) (*App, error) {
 
 	pages.AddPage("main", layout, true, true)
 
-	hib.palette = newCommandPalette(tviewApp, pages, hib.order, hib.switchService, func() {
+	hib.palette = newCommandPalette(tviewApp, pages, hib.order, func(name string) {
+		// Picking a service by hand starts a new trail.
+		hib.history = nil
+		hib.switchService(name)
+	}, func() {
 		if hib.current != nil {
 			hib.current.Activate()
 		}
@@ -116,6 +131,9 @@ func New(cfg *config.Config, factories []ServiceFactory) (*App, error) {
 
 // Run starts the event loop.
 func (a *App) Run() error {
+	if a.current == nil && a.start != nil {
+		a.navigate(a.start.Service, a.start.Args...)
+	}
 	if a.current == nil {
 		initial := serviceNameFromTabKey(a.cfg.TabKey)
 		if _, ok := a.services[initial]; !ok {
@@ -170,7 +188,13 @@ func (a *App) handleGlobalInput(event *tcell.EventKey) *tcell.EventKey {
 	}
 
 	if a.current != nil {
-		return a.current.HandleInput(event)
+		event = a.current.HandleInput(event)
+		// Esc that the service did not use to back out of a nested table
+		// returns to the service that linked here.
+		if event != nil && event.Key() == tcell.KeyEsc && a.back() {
+			return nil
+		}
+		return event
 	}
 
 	return event
@@ -199,12 +223,20 @@ func (a *App) updateHeader() {
 	title := ""
 	if a.current != nil {
 		title = fmt.Sprintf("%s", a.current.Title())
+		if len(a.history) > 0 {
+			title += fmt.Sprintf("  [gray](Esc returns to %s)[-]", a.history[len(a.history)-1])
+		}
 	} else {
 		title = "Select a service with :"
 	}
 
+	badge := ""
+	if a.firingAlarms > 0 {
+		badge = fmt.Sprintf("[white:red:b] %d ALARM [-:-:-]  ", a.firingAlarms)
+	}
+
 	helper := tview.Escape("[:]command  [/]filter  [R]efresh  [C]opy  [Esc]back  [Ctrl+C]quit")
-	a.header.SetText(fmt.Sprintf("[yellow]Hibiscus[-] – %s  %s", title, helper))
+	a.header.SetText(fmt.Sprintf("[yellow]Hibiscus[-] – %s%s  %s", badge, title, helper))
 }
 
 func (a *App) setStatus(msg string) {
@@ -214,6 +246,14 @@ func (a *App) setStatus(msg string) {
 	a.statusBar.SetText(fmt.Sprintf("[lightgreen]%s[-]", msg))
 }
 
+func (a *App) setFiringAlarms(count int) {
+	if count == a.firingAlarms {
+		return
+	}
+	a.firingAlarms = count
+	a.updateHeader()
+}
+
 func (a *App) setError(err error) {
 	if err == nil {
 		a.errorBar.SetText("")
@@ -228,6 +268,16 @@ func serviceNameFromTabKey(tab int) string {
 		return "route53"
 	case config.ELB_TAB:
 		return "elb"
+	case config.ECR_PUBLIC_TAB:
+		return "ecr-public"
+	case config.S3_TAB:
+		return "s3"
+	case config.EC2_TAB:
+		return "ec2"
+	case config.LOGS_TAB:
+		return "logs"
+	case config.ALARMS_TAB:
+		return "alarms"
 	case config.ECR_TAB:
 		fallthrough
 	default:
@@ -241,6 +291,16 @@ func serviceNameToTabKey(name string) int {
 		return config.ROUTE53_TAB
 	case "elb":
 		return config.ELB_TAB
+	case "ecr-public":
+		return config.ECR_PUBLIC_TAB
+	case "s3":
+		return config.S3_TAB
+	case "ec2":
+		return config.EC2_TAB
+	case "logs":
+		return config.LOGS_TAB
+	case "alarms":
+		return config.ALARMS_TAB
 	default:
 		return config.ECR_TAB
 	}
@@ -0,0 +1,83 @@
+package hibiscus
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxHistory bounds the cross-service back stack.
+const maxHistory = 50
+
+// Location identifies a service and, optionally, a resource inside it.
+type Location struct {
+	Service string
+	Args    []string
+}
+
+// ParseURI turns a deep link such as "route53://Z123/api.example.com" into a
+// Location. The scheme names the service and every path segment becomes a
+// navigation argument after URL unescaping.
+func ParseURI(uri string) (Location, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(uri), "://")
+	if !ok || scheme == "" {
+		return Location{}, fmt.Errorf("invalid uri %q: expected <service>://<resource>", uri)
+	}
+
+	loc := Location{Service: strings.ToLower(scheme)}
+	for _, segment := range strings.Split(strings.Trim(rest, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		value, err := url.PathUnescape(segment)
+		if err != nil {
+			return Location{}, fmt.Errorf("invalid uri %q: %w", uri, err)
+		}
+		loc.Args = append(loc.Args, value)
+	}
+	return loc, nil
+}
+
+// StartAt makes Run open the given location instead of the last used service.
+func (a *App) StartAt(loc Location) error {
+	name := strings.ToLower(strings.TrimSpace(loc.Service))
+	if _, ok := a.services[name]; !ok {
+		return fmt.Errorf("unknown service %q (available: %s)", loc.Service, strings.Join(a.order, ", "))
+	}
+	loc.Service = name
+	a.start = &loc
+	return nil
+}
+
+// navigate shows the named service and forwards args to it so it can select
+// the linked resource. The previous service is pushed on the back stack.
+func (a *App) navigate(name string, args ...string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	svc, ok := a.services[name]
+	if !ok {
+		a.setError(fmt.Errorf("unknown service %q", name))
+		return
+	}
+	if a.current != nil && a.current != svc {
+		a.history = append(a.history, strings.ToLower(a.current.Name()))
+		if len(a.history) > maxHistory {
+			a.history = a.history[len(a.history)-maxHistory:]
+		}
+	}
+	a.switchService(name)
+	if navigator, ok := svc.(Navigator); ok && len(args) > 0 {
+		navigator.Navigate(args...)
+	}
+}
+
+// back returns to the service that navigated to the current one. Services
+// keep their state, so it reappears as it was left.
+func (a *App) back() bool {
+	if len(a.history) == 0 {
+		return false
+	}
+	previous := a.history[len(a.history)-1]
+	a.history = a.history[:len(a.history)-1]
+	a.switchService(previous)
+	return true
+}
@@ -10,6 +10,12 @@ type ServiceContext struct {
 	App       *tview.Application
 	SetStatus func(string)
 	SetError  func(error)
+	// Navigate switches to another service and, when it implements
+	// Navigator, asks it to open the resource identified by args.
+	Navigate func(service string, args ...string)
+	// SetFiringAlarms updates the header badge counting CloudWatch alarms in
+	// ALARM state; zero hides it.
+	SetFiringAlarms func(count int)
 }
 
 // Service describes the contract each AWS view must implement so the
@@ -41,3 +47,11 @@ type Service interface {
 	// aren't handled globally. Return nil to consume the event.
 	HandleInput(event *tcell.EventKey) *tcell.EventKey
 }
+
+// Navigator is implemented by services that can open a specific resource
+// when another service links to it.
+type Navigator interface {
+	// Navigate opens the resource identified by args, e.g. a load balancer
+	// DNS name for "elb".
+	Navigate(args ...string)
+}
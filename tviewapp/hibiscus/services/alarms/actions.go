@@ -0,0 +1,193 @@
+package alarms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	awscloudwatch "github.com/jaehong21/hibiscus/internal/aws/cloudwatch"
+)
+
+// alarmAction turns the actions of a set of alarms on or off.
+type alarmAction struct {
+	label string
+	// verb describes the change in status messages.
+	verb  string
+	apply func(alarmNames []string) error
+}
+
+var (
+	enableAction  = alarmAction{label: "Enable", verb: "Enabling", apply: awscloudwatch.EnableAlarmActions}
+	disableAction = alarmAction{label: "Disable", verb: "Disabling", apply: awscloudwatch.DisableAlarmActions}
+)
+
+var silenceDurations = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"15 minutes", 15 * time.Minute},
+	{"1 hour", time.Hour},
+	{"4 hours", 4 * time.Hour},
+	{"1 day", 24 * time.Hour},
+}
+
+func (s *Service) confirmActions(action alarmAction) {
+	alarms := s.targetAlarms()
+	if len(alarms) == 0 {
+		return
+	}
+	names := alarmNames(alarms)
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s actions of %s?", action.label, describeAlarms(names))).
+		AddButtons([]string{"Cancel", action.label}).
+		SetDoneFunc(func(_ int, label string) {
+			s.closeModal()
+			if label == action.label {
+				s.runAction(alarms, action)
+			}
+		})
+
+	s.showModal(confirmModalPageName, centerPrimitive(modal, 70, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(modal)
+	}
+}
+
+// runAction applies action and forgets any silence of the alarms, since an
+// explicit enable or disable outlasts it.
+func (s *Service) runAction(alarms []alarmRow, action alarmAction) {
+	names := alarmNames(alarms)
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("%s actions of %s...", action.verb, describeAlarms(names)))
+
+	go func() {
+		err := action.apply(names)
+		if err == nil {
+			err = config.RemoveAlarmSilences(alarmArns(alarms))
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("%s alarm actions: %w", strings.ToLower(action.label), err))
+				return
+			}
+			s.marked = map[string]bool{}
+			s.ctx.SetStatus(fmt.Sprintf("%sd actions of %s", action.label, describeAlarms(names)))
+			s.loadAlarms()
+		})
+	}()
+}
+
+// openSilenceForm disables the actions of the target alarms for a chosen
+// time. Alarms whose actions were already disabled by hand are skipped, so
+// the silence never enables them.
+func (s *Service) openSilenceForm() {
+	var alarms []alarmRow
+	var skipped []string
+	for _, alarm := range s.targetAlarms() {
+		if _, silenced := s.silences[alarm.arn()]; !alarm.actionsEnabled() && !silenced {
+			skipped = append(skipped, alarm.name())
+			continue
+		}
+		alarms = append(alarms, alarm)
+	}
+	names := alarmNames(alarms)
+	if len(names) == 0 {
+		if len(skipped) > 0 {
+			s.ctx.SetStatus("Actions are already disabled; enable them with e first")
+		}
+		return
+	}
+
+	labels := make([]string, 0, len(silenceDurations))
+	for _, option := range silenceDurations {
+		labels = append(labels, option.label)
+	}
+	duration := silenceDurations[1].duration
+
+	note := "Actions are disabled now and enabled again once the silence ends."
+	if len(skipped) > 0 {
+		note += fmt.Sprintf(" Skipping %s, whose actions are already disabled.", describeAlarms(skipped))
+	}
+
+	form := tview.NewForm().
+		AddTextView("Alarms", describeAlarms(names), 0, 1, false, false).
+		AddTextView("Note", note, 0, 2, true, false).
+		AddDropDown("Silence for", labels, 1, func(_ string, idx int) {
+			if idx >= 0 {
+				duration = silenceDurations[idx].duration
+			}
+		})
+
+	form.AddButton("Silence", func() {
+		s.closeModal()
+		s.silenceAlarms(alarms, time.Now().Add(duration))
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Silence alarm actions")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(silenceModalPageName, centerPrimitive(form, 80, 13))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) silenceAlarms(alarms []alarmRow, until time.Time) {
+	names := alarmNames(alarms)
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Silencing %s...", describeAlarms(names)))
+
+	go func() {
+		err := awscloudwatch.DisableAlarmActions(names)
+		if err == nil {
+			silences := make([]config.AlarmSilence, 0, len(alarms))
+			for _, alarm := range alarms {
+				silences = append(silences, config.AlarmSilence{Alarm: alarm.name(), Arn: alarm.arn(), Until: until})
+			}
+			err = config.SaveAlarmSilences(silences)
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("silence alarms: %w", err))
+				return
+			}
+			s.marked = map[string]bool{}
+			s.ctx.SetStatus(fmt.Sprintf("Silenced %s until %s", describeAlarms(names), formatUntil(until, time.Now())))
+			s.loadAlarms()
+		})
+	}()
+}
+
+func alarmNames(rows []alarmRow) []string {
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.name())
+	}
+	return names
+}
+
+func alarmArns(rows []alarmRow) []string {
+	arns := make([]string, 0, len(rows))
+	for _, row := range rows {
+		arns = append(arns, row.arn())
+	}
+	return arns
+}
+
+// describeAlarms names a single alarm, or counts several.
+func describeAlarms(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return fmt.Sprintf("%d alarms", len(names))
+}
@@ -0,0 +1,213 @@
+package alarms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// alarmRow is either a metric or a composite alarm; exactly one is set.
+type alarmRow struct {
+	metric    *types.MetricAlarm
+	composite *types.CompositeAlarm
+}
+
+func (r alarmRow) name() string {
+	if r.composite != nil {
+		return valueOr(r.composite.AlarmName)
+	}
+	return valueOr(r.metric.AlarmName)
+}
+
+func (r alarmRow) arn() string {
+	if r.composite != nil {
+		return valueOr(r.composite.AlarmArn)
+	}
+	return valueOr(r.metric.AlarmArn)
+}
+
+func (r alarmRow) description() string {
+	if r.composite != nil {
+		return valueOr(r.composite.AlarmDescription)
+	}
+	return valueOr(r.metric.AlarmDescription)
+}
+
+func (r alarmRow) state() types.StateValue {
+	if r.composite != nil {
+		return r.composite.StateValue
+	}
+	return r.metric.StateValue
+}
+
+func (r alarmRow) stateReason() string {
+	if r.composite != nil {
+		return valueOr(r.composite.StateReason)
+	}
+	return valueOr(r.metric.StateReason)
+}
+
+// stateChanged is when the alarm last changed state. StateUpdatedTimestamp
+// also moves when only the reason changes, so it is the fallback.
+func (r alarmRow) stateChanged() *time.Time {
+	if r.composite != nil {
+		if r.composite.StateTransitionedTimestamp != nil {
+			return r.composite.StateTransitionedTimestamp
+		}
+		return r.composite.StateUpdatedTimestamp
+	}
+	if r.metric.StateTransitionedTimestamp != nil {
+		return r.metric.StateTransitionedTimestamp
+	}
+	return r.metric.StateUpdatedTimestamp
+}
+
+func (r alarmRow) actionsEnabled() bool {
+	var enabled *bool
+	if r.composite != nil {
+		enabled = r.composite.ActionsEnabled
+	} else {
+		enabled = r.metric.ActionsEnabled
+	}
+	return enabled == nil || *enabled
+}
+
+// actions lists the ALARM, OK and INSUFFICIENT_DATA actions in that order.
+func (r alarmRow) actions() []string {
+	var actions []string
+	for _, list := range r.actionLists() {
+		actions = append(actions, list.actions...)
+	}
+	return actions
+}
+
+type actionList struct {
+	state   types.StateValue
+	actions []string
+}
+
+func (r alarmRow) actionLists() []actionList {
+	if r.composite != nil {
+		return []actionList{
+			{types.StateValueAlarm, r.composite.AlarmActions},
+			{types.StateValueOk, r.composite.OKActions},
+			{types.StateValueInsufficientData, r.composite.InsufficientDataActions},
+		}
+	}
+	return []actionList{
+		{types.StateValueAlarm, r.metric.AlarmActions},
+		{types.StateValueOk, r.metric.OKActions},
+		{types.StateValueInsufficientData, r.metric.InsufficientDataActions},
+	}
+}
+
+// metricLabel names what the alarm watches: the metric with its dimensions,
+// the expression of a metric math alarm, or "composite".
+func (r alarmRow) metricLabel() string {
+	if r.composite != nil {
+		return "composite"
+	}
+	m := r.metric
+	if len(m.Metrics) > 0 {
+		for _, query := range m.Metrics {
+			if query.ReturnData != nil && !*query.ReturnData {
+				continue
+			}
+			if query.Expression != nil {
+				return "expression: " + *query.Expression
+			}
+			if query.MetricStat != nil && query.MetricStat.Metric != nil {
+				return metricName(query.MetricStat.Metric.Namespace, query.MetricStat.Metric.MetricName, query.MetricStat.Metric.Dimensions)
+			}
+		}
+		return fmt.Sprintf("metric math (%d metrics)", len(m.Metrics))
+	}
+	return metricName(m.Namespace, m.MetricName, m.Dimensions)
+}
+
+// condition summarises when the alarm fires, e.g. "Average > 80 (3 of 3 ×
+// 5m)". Composite alarms show their rule.
+func (r alarmRow) condition() string {
+	if r.composite != nil {
+		return valueOr(r.composite.AlarmRule)
+	}
+	m := r.metric
+
+	stat := string(m.Statistic)
+	if m.ExtendedStatistic != nil {
+		stat = *m.ExtendedStatistic
+	}
+	threshold := "-"
+	switch {
+	case m.ThresholdMetricId != nil:
+		threshold = *m.ThresholdMetricId
+	case m.Threshold != nil:
+		threshold = strconv.FormatFloat(*m.Threshold, 'g', -1, 64)
+	}
+
+	condition := strings.TrimSpace(fmt.Sprintf("%s %s %s", stat, comparison(m.ComparisonOperator), threshold))
+	if m.EvaluationPeriods != nil {
+		datapoints := *m.EvaluationPeriods
+		if m.DatapointsToAlarm != nil {
+			datapoints = *m.DatapointsToAlarm
+		}
+		period := ""
+		if m.Period != nil {
+			period = " × " + formatPeriod(*m.Period)
+		}
+		condition += fmt.Sprintf(" (%d of %d%s)", datapoints, *m.EvaluationPeriods, period)
+	}
+	return condition
+}
+
+// searchText is what the filter matches against.
+func (r alarmRow) searchText() string {
+	return strings.Join([]string{r.name(), string(r.state()), r.metricLabel(), r.description()}, " ")
+}
+
+func metricName(namespace, name *string, dimensions []types.Dimension) string {
+	label := strings.TrimSpace(valueOr(namespace) + " " + valueOr(name))
+	if len(dimensions) == 0 {
+		return label
+	}
+	pairs := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		pairs = append(pairs, valueOr(dimension.Name)+"="+valueOr(dimension.Value))
+	}
+	return fmt.Sprintf("%s (%s)", label, strings.Join(pairs, ", "))
+}
+
+func comparison(operator types.ComparisonOperator) string {
+	switch operator {
+	case types.ComparisonOperatorGreaterThanOrEqualToThreshold:
+		return ">="
+	case types.ComparisonOperatorGreaterThanThreshold:
+		return ">"
+	case types.ComparisonOperatorLessThanThreshold:
+		return "<"
+	case types.ComparisonOperatorLessThanOrEqualToThreshold:
+		return "<="
+	case types.ComparisonOperatorLessThanLowerOrGreaterThanUpperThreshold:
+		return "outside band"
+	case types.ComparisonOperatorLessThanLowerThreshold:
+		return "below band"
+	case types.ComparisonOperatorGreaterThanUpperThreshold:
+		return "above band"
+	}
+	return string(operator)
+}
+
+func formatPeriod(seconds int32) string {
+	switch {
+	case seconds%86400 == 0:
+		return fmt.Sprintf("%dd", seconds/86400)
+	case seconds%3600 == 0:
+		return fmt.Sprintf("%dh", seconds/3600)
+	case seconds%60 == 0:
+		return fmt.Sprintf("%dm", seconds/60)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
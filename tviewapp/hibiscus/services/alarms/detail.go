@@ -0,0 +1,148 @@
+package alarms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awscloudwatch "github.com/jaehong21/hibiscus/internal/aws/cloudwatch"
+)
+
+// openAlarmDetail shows the alarm configuration followed by its history,
+// which is fetched in the background.
+func (s *Service) openAlarmDetail() {
+	alarm, ok := s.selectedAlarm()
+	if !ok {
+		return
+	}
+	name := alarm.name()
+	config := s.formatAlarm(alarm)
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(config + "\n[yellow]Fetching history...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Alarm %s", name))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(detailModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching history for %s...", name))
+
+	go func() {
+		items, err := awscloudwatch.DescribeAlarmHistory(&name)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(config + fmt.Sprintf("\n[red]History unavailable: %s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("describe alarm history: %w", err))
+				return
+			}
+			view.SetText(config + "\n" + formatHistory(items))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d history items for %s", len(items), name))
+		})
+	}()
+}
+
+func (s *Service) formatAlarm(alarm alarmRow) string {
+	var b strings.Builder
+	field := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "[lightcyan]%-20s[-] %s\n", label, tview.Escape(value))
+	}
+
+	state := alarm.state()
+	since := formatTime(alarm.stateChanged())
+	fmt.Fprintf(&b, "[lightcyan]%-20s[-] [%s]%s[-] since %s\n", "State", stateTag(state), state, since)
+	field("Reason", alarm.stateReason())
+	field("Name", alarm.name())
+	field("ARN", alarm.arn())
+	field("Description", alarm.description())
+
+	if alarm.composite != nil {
+		field("Rule", valueOr(alarm.composite.AlarmRule))
+	} else {
+		m := alarm.metric
+		field("Metric", alarm.metricLabel())
+		field("Condition", alarm.condition())
+		field("Missing data", valueOr(m.TreatMissingData))
+		if m.Unit != "" {
+			field("Unit", string(m.Unit))
+		}
+		for _, query := range m.Metrics {
+			label := valueOr(query.Id)
+			switch {
+			case query.Expression != nil:
+				field("  "+label, *query.Expression)
+			case query.MetricStat != nil && query.MetricStat.Metric != nil:
+				metric := query.MetricStat.Metric
+				field("  "+label, fmt.Sprintf("%s %s", metricName(metric.Namespace, metric.MetricName, metric.Dimensions), valueOr(query.MetricStat.Stat)))
+			}
+		}
+	}
+
+	actions, _ := s.actionsLabel(alarm, time.Now())
+	field("Actions", actions)
+	for _, list := range alarm.actionLists() {
+		for _, action := range list.actions {
+			field("  On "+string(list.state), action)
+		}
+	}
+	return b.String()
+}
+
+func formatHistory(items []types.AlarmHistoryItem) string {
+	var b strings.Builder
+	b.WriteString("[yellow]History[-] (newest first)\n")
+	if len(items) == 0 {
+		b.WriteString("No history in the last two weeks\n")
+		return b.String()
+	}
+	for _, item := range items {
+		summary := tview.Escape(valueOr(item.HistorySummary))
+		if item.HistoryItemType == types.HistoryItemTypeStateUpdate {
+			summary = highlightStates(summary)
+		}
+		fmt.Fprintf(&b, "%s  [gray]%-20s[-] %s\n", formatTime(item.Timestamp), item.HistoryItemType, summary)
+	}
+	if len(items) >= awscloudwatch.HistoryLimit {
+		fmt.Fprintf(&b, "[gray]Showing the latest %d items[-]\n", awscloudwatch.HistoryLimit)
+	}
+	return b.String()
+}
+
+// highlightStates colours the state names in a state update summary such as
+// "Alarm updated from OK to ALARM".
+func highlightStates(summary string) string {
+	for _, state := range []types.StateValue{types.StateValueInsufficientData, types.StateValueAlarm, types.StateValueOk} {
+		summary = strings.ReplaceAll(summary, " "+string(state), fmt.Sprintf(" [%s]%s[-]", stateTag(state), state))
+	}
+	return summary
+}
+
+func stateTag(state types.StateValue) string {
+	switch state {
+	case types.StateValueAlarm:
+		return "red"
+	case types.StateValueOk:
+		return "green"
+	}
+	return "yellow"
+}
@@ -0,0 +1,579 @@
+package alarms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	awscloudwatch "github.com/jaehong21/hibiscus/internal/aws/cloudwatch"
+	"github.com/jaehong21/hibiscus/tviewapp/hibiscus"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+const (
+	contentPageName      = "alarms-content"
+	detailModalPageName  = "alarms-detail-modal"
+	confirmModalPageName = "alarms-confirm-modal"
+	silenceModalPageName = "alarms-silence-modal"
+)
+
+const alarmTableTitle = "CloudWatch alarms"
+
+// watchInterval is how often the header badge is refreshed and expired
+// silences are lifted, whether or not the service is visible.
+const watchInterval = time.Minute
+
+// Service implements the hibiscus.Service interface for CloudWatch alarms.
+type Service struct {
+	ctx hibiscus.ServiceContext
+
+	root       *tview.Pages
+	layout     *tview.Flex
+	filter     *tview.InputField
+	alarmTable *tview.Table
+
+	alarms         []alarmRow
+	filteredAlarms []alarmRow
+	query          string
+	// alarmOnly hides alarms that are not in ALARM state.
+	alarmOnly bool
+	// silences maps alarm ARNs to the time their actions are enabled again.
+	silences map[string]time.Time
+	// marked are the alarms the next action applies to.
+	marked map[string]bool
+
+	active      bool
+	activeModal string
+}
+
+func New(ctx hibiscus.ServiceContext) hibiscus.Service {
+	svc := &Service{
+		ctx:      ctx,
+		silences: map[string]time.Time{},
+		marked:   map[string]bool{},
+	}
+
+	svc.filter = tview.NewInputField().
+		SetLabel("Filter (/): ").
+		SetPlaceholder("alarm name, metric or state").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+
+	svc.alarmTable = buildTable(alarmTableTitle)
+
+	svc.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(svc.filter, 1, 0, true).
+		AddItem(svc.alarmTable, 0, 1, true)
+
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
+	svc.filter.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			svc.applyFilter(strings.TrimSpace(svc.filter.GetText()))
+		case tcell.KeyEsc:
+			svc.exitFilterMode()
+		}
+	})
+
+	return svc
+}
+
+func (s *Service) Name() string  { return "alarms" }
+func (s *Service) Title() string { return "Amazon CloudWatch – alarms" }
+func (s *Service) Primitive() tview.Primitive {
+	return s.root
+}
+
+func (s *Service) Init() {
+	s.loadAlarms()
+	go s.watchAlarms()
+}
+
+func (s *Service) Activate() {
+	s.active = true
+	s.focusCurrentTable()
+}
+
+func (s *Service) Deactivate() {
+	s.active = false
+}
+
+func (s *Service) Refresh() {
+	s.loadAlarms()
+}
+
+func (s *Service) EnterFilterMode() bool {
+	if !s.canFocus() || s.modalVisible() {
+		return false
+	}
+	s.ctx.App.SetFocus(s.filter)
+	return true
+}
+
+// InFilterMode also reports true while a modal is open so typing into its
+// fields does not trigger global shortcuts.
+func (s *Service) InFilterMode() bool {
+	return s.filter.HasFocus() || s.modalVisible()
+}
+
+func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event == nil {
+		return nil
+	}
+
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		if s.filter.HasFocus() {
+			s.exitFilterMode()
+			return nil
+		}
+	case tcell.KeyEnter:
+		if s.alarmTable.HasFocus() {
+			s.openAlarmDetail()
+			return nil
+		}
+	}
+
+	if !s.alarmTable.HasFocus() {
+		return event
+	}
+
+	switch event.Rune() {
+	case ' ':
+		s.toggleMark()
+		return nil
+	case 'a':
+		s.alarmOnly = !s.alarmOnly
+		s.applyQuery()
+		s.renderAlarms()
+		return nil
+	case 'e':
+		s.confirmActions(enableAction)
+		return nil
+	case 'd':
+		s.confirmActions(disableAction)
+		return nil
+	case 's':
+		s.openSilenceForm()
+		return nil
+	}
+
+	return event
+}
+
+func (s *Service) exitFilterMode() {
+	s.filter.SetText("")
+	if s.modalVisible() {
+		return
+	}
+	s.focusCurrentTable()
+}
+
+func (s *Service) loadAlarms() {
+	s.ctx.SetStatus("Fetching alarms...")
+	s.ctx.SetError(nil)
+
+	go func() {
+		metricAlarms, compositeAlarms, err := awscloudwatch.DescribeAlarms("")
+		silences, silenceErr := config.LoadAlarmSilences()
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe alarms: %w", err))
+				return
+			}
+			if silenceErr != nil {
+				s.ctx.SetError(fmt.Errorf("load alarm silences: %w", silenceErr))
+			}
+
+			rows := make([]alarmRow, 0, len(metricAlarms)+len(compositeAlarms))
+			for idx := range metricAlarms {
+				rows = append(rows, alarmRow{metric: &metricAlarms[idx]})
+			}
+			for idx := range compositeAlarms {
+				rows = append(rows, alarmRow{composite: &compositeAlarms[idx]})
+			}
+			sort.SliceStable(rows, func(i, j int) bool {
+				a, b := statePriority(rows[i].state()), statePriority(rows[j].state())
+				if a != b {
+					return a < b
+				}
+				return rows[i].name() < rows[j].name()
+			})
+
+			s.alarms = rows
+			s.silences = map[string]time.Time{}
+			for _, silence := range silences {
+				s.silences[silence.Arn] = silence.Until
+			}
+			// Drop marks of alarms that no longer exist.
+			names := map[string]bool{}
+			for _, row := range rows {
+				names[row.name()] = true
+			}
+			for name := range s.marked {
+				if !names[name] {
+					delete(s.marked, name)
+				}
+			}
+
+			s.applyQuery()
+			s.renderAlarms()
+			s.focusCurrentTable()
+			s.ctx.SetFiringAlarms(s.firingCount())
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d alarms", len(rows)))
+		})
+	}()
+}
+
+// watchAlarms keeps the header badge current and lifts expired silences for
+// the lifetime of the application. Errors are left to the next load of the
+// table to report.
+func (s *Service) watchAlarms() {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		lifted, liftErr := liftExpiredSilences(time.Now())
+		metricAlarms, compositeAlarms, err := awscloudwatch.DescribeAlarms(types.StateValueAlarm)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err == nil {
+				s.ctx.SetFiringAlarms(len(metricAlarms) + len(compositeAlarms))
+			}
+			if liftErr != nil {
+				s.ctx.SetError(fmt.Errorf("enable silenced alarm actions: %w", liftErr))
+			}
+			if len(lifted) > 0 {
+				s.ctx.SetStatus(fmt.Sprintf("Silence ended, actions enabled for %s", strings.Join(lifted, ", ")))
+				s.loadAlarms()
+			}
+		})
+	}
+}
+
+// liftExpiredSilences enables the actions of alarms whose silence ended
+// before now and forgets those silences, returning the alarm names. Only
+// silences of alarms the current profile and region can see are lifted;
+// the others wait for a session with the account they were made in.
+func liftExpiredSilences(now time.Time) ([]string, error) {
+	silences, err := config.LoadAlarmSilences()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, silence := range silences {
+		if name := awscloudwatch.AlarmName(silence.Arn); name != "" && !silence.Until.After(now) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	visible, err := awscloudwatch.AlarmArns(candidates)
+	if err != nil {
+		return nil, err
+	}
+	var names, arns []string
+	for _, silence := range silences {
+		if visible[silence.Arn] && !silence.Until.After(now) {
+			names = append(names, awscloudwatch.AlarmName(silence.Arn))
+			arns = append(arns, silence.Arn)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if err := awscloudwatch.EnableAlarmActions(names); err != nil {
+		return nil, err
+	}
+	return names, config.RemoveAlarmSilences(arns)
+}
+
+func (s *Service) applyFilter(query string) {
+	s.ctx.SetError(nil)
+	s.query = query
+	s.applyQuery()
+	s.renderAlarms()
+
+	s.filter.SetText("")
+	s.exitFilterMode()
+}
+
+func (s *Service) applyQuery() {
+	query := strings.ToLower(s.query)
+	s.filteredAlarms = s.filteredAlarms[:0]
+	for _, row := range s.alarms {
+		if s.alarmOnly && row.state() != types.StateValueAlarm {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(row.searchText()), query) {
+			continue
+		}
+		s.filteredAlarms = append(s.filteredAlarms, row)
+	}
+}
+
+func (s *Service) renderAlarms() {
+	table := s.alarmTable
+	table.Clear()
+
+	title := alarmTableTitle
+	if firing := s.firingCount(); firing > 0 {
+		title += fmt.Sprintf(" – %d in ALARM", firing)
+	}
+	if s.alarmOnly {
+		title += " (ALARM only)"
+	}
+	if s.query != "" {
+		title += fmt.Sprintf(" (filter: %s)", s.query)
+	}
+	if len(s.marked) > 0 {
+		title += fmt.Sprintf(" – %d marked", len(s.marked))
+	}
+	table.SetTitle(title)
+
+	headers := []string{"Alarm", "State", "Metric", "Condition", "Actions", "Last state change", "Age"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredAlarms) == 0 {
+		msg := "No alarms found"
+		switch {
+		case len(s.alarms) > 0 && s.query == "" && s.alarmOnly:
+			msg = "No alarms are firing"
+		case len(s.alarms) > 0:
+			msg = "No alarms match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	row, _ := table.GetSelection()
+	now := time.Now()
+	for idx, alarm := range s.filteredAlarms {
+		name := alarm.name()
+		if s.marked[name] {
+			name = "✓ " + name
+		}
+		state := alarm.state()
+		changed := alarm.stateChanged()
+		age := "-"
+		if changed != nil {
+			age = utils.GetAgeFromTime(changed)
+		}
+		actions, actionsColor := s.actionsLabel(alarm, now)
+
+		table.SetCell(idx+1, 0, tableCell(name))
+		table.SetCell(idx+1, 1, tableCell(string(state)).SetTextColor(stateColor(state)))
+		table.SetCell(idx+1, 2, tableCell(alarm.metricLabel()))
+		table.SetCell(idx+1, 3, tableCell(alarm.condition()))
+		table.SetCell(idx+1, 4, tableCell(actions).SetTextColor(actionsColor))
+		table.SetCell(idx+1, 5, tableCell(formatTime(changed)))
+		table.SetCell(idx+1, 6, tableCell(age))
+	}
+
+	if row <= 0 || row > len(s.filteredAlarms) {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+// actionsLabel describes whether the alarm's actions run: enabled, disabled,
+// or silenced until a time.
+func (s *Service) actionsLabel(alarm alarmRow, now time.Time) (string, tcell.Color) {
+	if until, ok := s.silences[alarm.arn()]; ok && until.After(now) {
+		return "silenced until " + formatUntil(until, now), tcell.ColorYellow
+	}
+	if !alarm.actionsEnabled() {
+		return "disabled", tcell.ColorGray
+	}
+	count := len(alarm.actions())
+	if count == 0 {
+		return "none", tcell.ColorGray
+	}
+	return fmt.Sprintf("enabled (%d)", count), tcell.ColorGreen
+}
+
+func (s *Service) firingCount() int {
+	count := 0
+	for _, row := range s.alarms {
+		if row.state() == types.StateValueAlarm {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Service) toggleMark() {
+	alarm, ok := s.selectedAlarm()
+	if !ok {
+		return
+	}
+	name := alarm.name()
+	if s.marked[name] {
+		delete(s.marked, name)
+	} else {
+		s.marked[name] = true
+	}
+	row, _ := s.alarmTable.GetSelection()
+	s.renderAlarms()
+	s.alarmTable.Select(min(row+1, len(s.filteredAlarms)), 0)
+	s.ctx.SetStatus(fmt.Sprintf("%d alarms marked – e/d/s apply to them", len(s.marked)))
+}
+
+func (s *Service) selectedAlarm() (alarmRow, bool) {
+	row, _ := s.alarmTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredAlarms) {
+		return alarmRow{}, false
+	}
+	return s.filteredAlarms[row-1], true
+}
+
+// targetAlarms are the marked alarms, or else the selected one.
+func (s *Service) targetAlarms() []alarmRow {
+	if len(s.marked) == 0 {
+		if alarm, ok := s.selectedAlarm(); ok {
+			return []alarmRow{alarm}
+		}
+		return nil
+	}
+	var rows []alarmRow
+	for _, row := range s.alarms {
+		if s.marked[row.name()] {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func statePriority(state types.StateValue) int {
+	switch state {
+	case types.StateValueAlarm:
+		return 0
+	case types.StateValueInsufficientData:
+		return 1
+	}
+	return 2
+}
+
+func stateColor(state types.StateValue) tcell.Color {
+	switch state {
+	case types.StateValueAlarm:
+		return tcell.ColorRed
+	case types.StateValueOk:
+		return tcell.ColorGreen
+	}
+	return tcell.ColorYellow
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatUntil leaves out the date for times later today.
+func formatUntil(t, now time.Time) string {
+	t = t.Local()
+	if y, m, d := now.Local().Date(); t.Year() == y && t.Month() == m && t.Day() == d {
+		return t.Format("15:04")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func headerCell(title string) *tview.TableCell {
+	return tview.NewTableCell(title).
+		SetTextColor(tcell.ColorLightCyan).
+		SetSelectable(false).
+		SetAlign(tview.AlignLeft)
+}
+
+func tableCell(value string) *tview.TableCell {
+	return tview.NewTableCell(value).
+		SetExpansion(1)
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func buildTable(title string) *tview.Table {
+	tbl := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	tbl.SetBorder(true)
+	tbl.SetTitle(title)
+	tbl.SetBorderColor(tcell.ColorDimGray)
+	return tbl
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
+
+func (s *Service) canFocus() bool {
+	return s.ctx.App != nil && s.active
+}
+
+func (s *Service) setFocus(p tview.Primitive) {
+	if !s.canFocus() || p == nil {
+		return
+	}
+	s.ctx.App.SetFocus(p)
+}
+
+func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
+	s.setFocus(s.alarmTable)
+}
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
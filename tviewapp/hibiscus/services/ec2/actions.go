@@ -0,0 +1,165 @@
+package ec2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/rivo/tview"
+
+	awsec2 "github.com/jaehong21/hibiscus/internal/aws/ec2"
+)
+
+// instanceAction is a state change offered on an instance.
+type instanceAction struct {
+	label string
+	// verb describes the change in status messages.
+	verb  string
+	apply func(instanceID *string) error
+}
+
+var (
+	startAction     = instanceAction{label: "Start", verb: "Starting", apply: awsec2.StartInstance}
+	stopAction      = instanceAction{label: "Stop", verb: "Stopping", apply: awsec2.StopInstance}
+	rebootAction    = instanceAction{label: "Reboot", verb: "Rebooting", apply: awsec2.RebootInstance}
+	terminateAction = instanceAction{label: "Terminate", verb: "Terminating", apply: awsec2.TerminateInstance}
+)
+
+// availableActions lists the actions that make sense in the instance's state.
+func availableActions(state types.InstanceStateName) []instanceAction {
+	switch state {
+	case types.InstanceStateNameRunning:
+		return []instanceAction{stopAction, rebootAction, terminateAction}
+	case types.InstanceStateNameStopped:
+		return []instanceAction{startAction, terminateAction}
+	case types.InstanceStateNamePending, types.InstanceStateNameStopping:
+		return []instanceAction{terminateAction}
+	}
+	return nil
+}
+
+func (s *Service) openActions() {
+	instance, ok := s.selectedInstance()
+	if !ok {
+		return
+	}
+	state := instanceState(instance)
+	actions := availableActions(state)
+	if len(actions) == 0 {
+		s.ctx.SetStatus(fmt.Sprintf("No actions available while the instance is %s", state))
+		return
+	}
+
+	labels := make([]string, 0, len(actions)+1)
+	for _, action := range actions {
+		labels = append(labels, action.label)
+	}
+	labels = append(labels, "Cancel")
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s\n(%s, %s)", instanceLabel(instance), instance.InstanceType, state)).
+		AddButtons(labels).
+		SetDoneFunc(func(idx int, _ string) {
+			s.closeModal()
+			if idx < 0 || idx >= len(actions) {
+				return
+			}
+			if actions[idx].label == terminateAction.label {
+				s.confirmTerminate(instance)
+				return
+			}
+			s.confirmAction(instance, actions[idx])
+		})
+
+	s.showModal(actionModalPageName, centerPrimitive(modal, 70, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(modal)
+	}
+}
+
+func (s *Service) confirmAction(instance types.Instance, action instanceAction) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s %s?", action.label, instanceLabel(instance))).
+		AddButtons([]string{"Cancel", action.label}).
+		SetDoneFunc(func(_ int, label string) {
+			s.closeModal()
+			if label == action.label {
+				s.runAction(instance, action)
+			}
+		})
+
+	s.showModal(confirmModalPageName, centerPrimitive(modal, 70, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(modal)
+	}
+}
+
+// confirmTerminate asks for the instance ID to be typed before terminating,
+// as termination cannot be undone. The instance is the one the action was
+// chosen for, even if a refresh has moved the table selection since.
+func (s *Service) confirmTerminate(instance types.Instance) {
+	state := instanceState(instance)
+	if state == types.InstanceStateNameTerminated || state == types.InstanceStateNameShuttingDown {
+		s.ctx.SetStatus(fmt.Sprintf("Instance is already %s", state))
+		return
+	}
+	id := valueOr(instance.InstanceId)
+
+	input := tview.NewInputField().
+		SetLabel("Instance ID: ")
+
+	form := tview.NewForm().
+		AddTextView("Instance", instanceLabel(instance), 0, 1, false, false).
+		AddTextView("Warning", "Termination cannot be undone; volumes marked delete-on-termination are deleted too.", 0, 2, true, false).
+		AddFormItem(input)
+
+	form.AddButton("Terminate", func() {
+		if strings.TrimSpace(input.GetText()) != id {
+			s.ctx.SetError(fmt.Errorf("type %s to confirm termination", id))
+			return
+		}
+		s.closeModal()
+		s.runAction(instance, terminateAction)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Terminate instance")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(confirmModalPageName, centerPrimitive(form, 80, 12))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) runAction(instance types.Instance, action instanceAction) {
+	id := valueOr(instance.InstanceId)
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("%s %s...", action.verb, id))
+
+	go func() {
+		err := action.apply(&id)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("%s %s: %w", strings.ToLower(action.label), id, err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("%s %s requested", action.label, id))
+			s.pendingInstance = id
+			s.loadInstances()
+		})
+	}()
+}
+
+func instanceLabel(instance types.Instance) string {
+	id := valueOr(instance.InstanceId)
+	if name := awsec2.InstanceName(instance); name != "" {
+		return fmt.Sprintf("%s (%s)", name, id)
+	}
+	return id
+}
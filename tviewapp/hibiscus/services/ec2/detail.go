@@ -0,0 +1,276 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awsec2 "github.com/jaehong21/hibiscus/internal/aws/ec2"
+)
+
+// instanceDetail gathers the security groups and volumes of an instance.
+// Lookup errors are kept per section and rendered inline.
+type instanceDetail struct {
+	groups    []types.SecurityGroup
+	groupsErr error
+	volumes   map[string]types.Volume
+	volumeErr error
+}
+
+func (s *Service) openInstanceDetail() {
+	instance, ok := s.selectedInstance()
+	if !ok {
+		return
+	}
+	id := valueOr(instance.InstanceId)
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching security groups and volumes...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Instance %s", id))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(detailModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching details for %s...", id))
+
+	go func() {
+		detail := describeInstanceDetail(instance)
+		s.ctx.App.QueueUpdateDraw(func() {
+			view.SetText(formatInstanceDetail(instance, detail))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded details for %s", id))
+		})
+	}()
+}
+
+func describeInstanceDetail(instance types.Instance) *instanceDetail {
+	detail := &instanceDetail{volumes: map[string]types.Volume{}}
+
+	var groupIDs []string
+	for _, group := range instance.SecurityGroups {
+		if group.GroupId != nil {
+			groupIDs = append(groupIDs, *group.GroupId)
+		}
+	}
+	detail.groups, detail.groupsErr = awsec2.DescribeSecurityGroups(groupIDs)
+
+	var volumeIDs []string
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs != nil && mapping.Ebs.VolumeId != nil {
+			volumeIDs = append(volumeIDs, *mapping.Ebs.VolumeId)
+		}
+	}
+	volumes, err := awsec2.DescribeVolumes(volumeIDs)
+	detail.volumeErr = err
+	for _, volume := range volumes {
+		detail.volumes[valueOr(volume.VolumeId)] = volume
+	}
+
+	return detail
+}
+
+func formatInstanceDetail(instance types.Instance, detail *instanceDetail) string {
+	var b strings.Builder
+
+	section(&b, "Instance")
+	field(&b, "Name", awsec2.InstanceName(instance))
+	field(&b, "ID", valueOr(instance.InstanceId))
+	field(&b, "Type", string(instance.InstanceType))
+	state := string(instanceState(instance))
+	if instance.StateReason != nil && instance.StateReason.Message != nil {
+		state = fmt.Sprintf("%s (%s)", state, *instance.StateReason.Message)
+	}
+	field(&b, "State", state)
+	field(&b, "AMI", valueOr(instance.ImageId))
+	field(&b, "Platform", valueOr(instance.PlatformDetails))
+	field(&b, "Architecture", string(instance.Architecture))
+	field(&b, "Key pair", valueOr(instance.KeyName))
+	field(&b, "Launched", formatTime(instance.LaunchTime))
+	if instance.IamInstanceProfile != nil {
+		field(&b, "IAM profile", valueOr(instance.IamInstanceProfile.Arn))
+	}
+	if instance.Placement != nil {
+		field(&b, "AZ", valueOr(instance.Placement.AvailabilityZone))
+	}
+	field(&b, "VPC", valueOr(instance.VpcId))
+	field(&b, "Subnet", valueOr(instance.SubnetId))
+	field(&b, "Private IP", valueOr(instance.PrivateIpAddress))
+	field(&b, "Private DNS", valueOr(instance.PrivateDnsName))
+	field(&b, "Public IP", valueOr(instance.PublicIpAddress))
+	field(&b, "Public DNS", valueOr(instance.PublicDnsName))
+
+	section(&b, "Tags")
+	tags := append([]types.Tag(nil), instance.Tags...)
+	sort.Slice(tags, func(i, j int) bool {
+		return valueOr(tags[i].Key) < valueOr(tags[j].Key)
+	})
+	if len(tags) == 0 {
+		b.WriteString("  [gray]none[-]\n")
+	}
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "  [lightcyan]%-30s[-] %s\n", tview.Escape(valueOr(tag.Key)), tview.Escape(valueOr(tag.Value)))
+	}
+
+	section(&b, "Security groups")
+	switch {
+	case detail.groupsErr != nil:
+		fmt.Fprintf(&b, "  [red]%s[-]\n", tview.Escape(detail.groupsErr.Error()))
+	case len(detail.groups) == 0:
+		b.WriteString("  [gray]none[-]\n")
+	}
+	for _, group := range detail.groups {
+		fmt.Fprintf(&b, "  [white]%s[-] %s\n", valueOr(group.GroupId), tview.Escape(valueOr(group.GroupName)))
+		if len(group.IpPermissions) == 0 {
+			b.WriteString("    [gray]no inbound rules[-]\n")
+		}
+		for _, permission := range group.IpPermissions {
+			fmt.Fprintf(&b, "    inbound  %-14s from %s\n", formatPorts(permission), tview.Escape(strings.Join(permissionSources(permission), ", ")))
+		}
+	}
+
+	section(&b, "Volumes")
+	if detail.volumeErr != nil {
+		fmt.Fprintf(&b, "  [red]%s[-]\n", tview.Escape(detail.volumeErr.Error()))
+	}
+	if len(instance.BlockDeviceMappings) == 0 {
+		b.WriteString("  [gray]none[-]\n")
+	}
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		id := valueOr(mapping.Ebs.VolumeId)
+		line := fmt.Sprintf("  %-12s %s", valueOr(mapping.DeviceName), id)
+		if volume, ok := detail.volumes[id]; ok {
+			line += fmt.Sprintf("  %s %s", formatGiB(volume.Size), volume.VolumeType)
+			if volume.Iops != nil {
+				line += fmt.Sprintf(" %d IOPS", *volume.Iops)
+			}
+			if volume.Throughput != nil {
+				line += fmt.Sprintf(" %d MiB/s", *volume.Throughput)
+			}
+			if volume.Encrypted != nil && *volume.Encrypted {
+				line += " encrypted"
+			}
+			line += fmt.Sprintf("  %s", volume.State)
+		}
+		if mapping.Ebs.DeleteOnTermination != nil && *mapping.Ebs.DeleteOnTermination {
+			line += "  [gray]deleted on termination[-]"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	section(&b, "Network interfaces")
+	if len(instance.NetworkInterfaces) == 0 {
+		b.WriteString("  [gray]none[-]\n")
+	}
+	interfaces := append([]types.InstanceNetworkInterface(nil), instance.NetworkInterfaces...)
+	sort.Slice(interfaces, func(i, j int) bool {
+		return deviceIndex(interfaces[i]) < deviceIndex(interfaces[j])
+	})
+	for _, eni := range interfaces {
+		fmt.Fprintf(&b, "  [white]eth%d[-] %s  %s  %s\n", deviceIndex(eni), valueOr(eni.NetworkInterfaceId), valueOr(eni.SubnetId), eni.Status)
+		for _, address := range eni.PrivateIpAddresses {
+			line := fmt.Sprintf("    %s", valueOr(address.PrivateIpAddress))
+			if address.Association != nil && address.Association.PublicIp != nil {
+				line += fmt.Sprintf(" → %s", *address.Association.PublicIp)
+			}
+			if address.Primary != nil && *address.Primary {
+				line += " [gray](primary)[-]"
+			}
+			b.WriteString(line + "\n")
+		}
+		var groups []string
+		for _, group := range eni.Groups {
+			groups = append(groups, valueOr(group.GroupId))
+		}
+		if len(groups) > 0 {
+			fmt.Fprintf(&b, "    groups %s\n", strings.Join(groups, ", "))
+		}
+	}
+
+	return b.String()
+}
+
+func formatPorts(permission types.IpPermission) string {
+	protocol := valueOr(permission.IpProtocol)
+	if protocol == "-1" {
+		return "all traffic"
+	}
+	if permission.FromPort == nil || permission.ToPort == nil {
+		return protocol
+	}
+	from, to := *permission.FromPort, *permission.ToPort
+	switch {
+	case from == -1 || (from == 0 && to == 65535):
+		return protocol + " all"
+	case from == to:
+		return fmt.Sprintf("%s %d", protocol, from)
+	}
+	return fmt.Sprintf("%s %d-%d", protocol, from, to)
+}
+
+func permissionSources(permission types.IpPermission) []string {
+	var sources []string
+	for _, ipRange := range permission.IpRanges {
+		sources = append(sources, valueOr(ipRange.CidrIp))
+	}
+	for _, ipRange := range permission.Ipv6Ranges {
+		sources = append(sources, valueOr(ipRange.CidrIpv6))
+	}
+	for _, pair := range permission.UserIdGroupPairs {
+		sources = append(sources, valueOr(pair.GroupId))
+	}
+	for _, prefixList := range permission.PrefixListIds {
+		sources = append(sources, valueOr(prefixList.PrefixListId))
+	}
+	if len(sources) == 0 {
+		return []string{"-"}
+	}
+	return sources
+}
+
+func deviceIndex(eni types.InstanceNetworkInterface) int32 {
+	if eni.Attachment == nil || eni.Attachment.DeviceIndex == nil {
+		return 0
+	}
+	return *eni.Attachment.DeviceIndex
+}
+
+func formatGiB(size *int32) string {
+	if size == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%dGiB", *size)
+}
+
+func section(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "[yellow]%s[-]\n", title)
+}
+
+func field(b *strings.Builder, label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "  [lightcyan]%-14s[-] %s\n", label, tview.Escape(value))
+}
@@ -0,0 +1,108 @@
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	awsec2 "github.com/jaehong21/hibiscus/internal/aws/ec2"
+)
+
+// instanceQuery is a parsed filter such as "state=running tag:env=prod web".
+// Every term must match: state= takes a comma separated list of states,
+// tag:Key or tag:Key=value matches a tag (the value as a substring), and any
+// other word is searched in the name, ID, type, IPs and AZ.
+type instanceQuery struct {
+	raw    string
+	states []string
+	tags   []tagTerm
+	words  []string
+}
+
+type tagTerm struct {
+	key   string
+	value string
+	// any is set for "tag:Key" without a value.
+	any bool
+}
+
+func parseInstanceQuery(query string) instanceQuery {
+	q := instanceQuery{raw: strings.TrimSpace(query)}
+	for _, term := range strings.Fields(q.raw) {
+		lower := strings.ToLower(term)
+		switch {
+		case strings.HasPrefix(lower, "state="):
+			for _, state := range strings.Split(lower[len("state="):], ",") {
+				if state != "" {
+					q.states = append(q.states, state)
+				}
+			}
+		case strings.HasPrefix(lower, "tag:"):
+			key, value, ok := strings.Cut(term[len("tag:"):], "=")
+			q.tags = append(q.tags, tagTerm{key: key, value: strings.ToLower(value), any: !ok})
+		default:
+			q.words = append(q.words, lower)
+		}
+	}
+	return q
+}
+
+func (q instanceQuery) empty() bool {
+	return len(q.states) == 0 && len(q.tags) == 0 && len(q.words) == 0
+}
+
+func (q instanceQuery) matches(instance types.Instance) bool {
+	if len(q.states) > 0 {
+		state := string(instanceState(instance))
+		found := false
+		for _, want := range q.states {
+			if state == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, term := range q.tags {
+		if !term.matches(instance.Tags) {
+			return false
+		}
+	}
+
+	if len(q.words) > 0 {
+		fields := []string{
+			awsec2.InstanceName(instance),
+			valueOr(instance.InstanceId),
+			string(instance.InstanceType),
+			valueOr(instance.PrivateIpAddress),
+			valueOr(instance.PublicIpAddress),
+		}
+		if instance.Placement != nil {
+			fields = append(fields, valueOr(instance.Placement.AvailabilityZone))
+		}
+		haystack := strings.ToLower(strings.Join(fields, " "))
+		for _, word := range q.words {
+			if !strings.Contains(haystack, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matches compares tag keys case-insensitively, as keys are usually typed
+// from memory.
+func (t tagTerm) matches(tags []types.Tag) bool {
+	for _, tag := range tags {
+		if !strings.EqualFold(valueOr(tag.Key), t.key) {
+			continue
+		}
+		if t.any || strings.Contains(strings.ToLower(valueOr(tag.Value)), t.value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,464 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awsec2 "github.com/jaehong21/hibiscus/internal/aws/ec2"
+	"github.com/jaehong21/hibiscus/tviewapp/hibiscus"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+const (
+	contentPageName      = "ec2-content"
+	detailModalPageName  = "ec2-detail-modal"
+	actionModalPageName  = "ec2-action-modal"
+	confirmModalPageName = "ec2-confirm-modal"
+	forwardModalPageName = "ec2-forward-modal"
+)
+
+const instanceTableTitle = "EC2 instances"
+
+// Service implements the hibiscus.Service interface for Amazon EC2.
+type Service struct {
+	ctx hibiscus.ServiceContext
+
+	root          *tview.Pages
+	layout        *tview.Flex
+	filter        *tview.InputField
+	instanceTable *tview.Table
+
+	instances         []types.Instance
+	filteredInstances []types.Instance
+	query             instanceQuery
+
+	// pendingInstance is the instance (ID or Name tag) another service asked
+	// to show; it is selected once the instances are loaded.
+	pendingInstance  string
+	loadingInstances bool
+
+	mu     sync.Mutex
+	active bool
+
+	activeModal string
+}
+
+func New(ctx hibiscus.ServiceContext) hibiscus.Service {
+	svc := &Service{
+		ctx: ctx,
+	}
+
+	svc.filter = tview.NewInputField().
+		SetLabel("Filter (/): ").
+		SetPlaceholder("state=running tag:env=prod web").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+
+	svc.instanceTable = buildTable(instanceTableTitle)
+
+	svc.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(svc.filter, 1, 0, true).
+		AddItem(svc.instanceTable, 0, 1, true)
+
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
+	svc.filter.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			svc.applyFilter(strings.TrimSpace(svc.filter.GetText()))
+		case tcell.KeyEsc:
+			svc.exitFilterMode()
+		}
+	})
+
+	return svc
+}
+
+func (s *Service) Name() string  { return "ec2" }
+func (s *Service) Title() string { return "Amazon EC2 – instances" }
+func (s *Service) Primitive() tview.Primitive {
+	return s.root
+}
+
+func (s *Service) Init() {
+	s.loadInstances()
+}
+
+func (s *Service) Activate() {
+	s.active = true
+	s.focusCurrentTable()
+}
+
+func (s *Service) Deactivate() {
+	s.active = false
+}
+
+func (s *Service) Refresh() {
+	s.loadInstances()
+}
+
+func (s *Service) EnterFilterMode() bool {
+	if !s.canFocus() || s.modalVisible() {
+		return false
+	}
+	s.ctx.App.SetFocus(s.filter)
+	return true
+}
+
+// InFilterMode also reports true while a modal is open so typing into its
+// text fields does not trigger global shortcuts.
+func (s *Service) InFilterMode() bool {
+	return s.filter.HasFocus() || s.modalVisible()
+}
+
+func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event == nil {
+		return nil
+	}
+
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		if s.filter.HasFocus() {
+			s.exitFilterMode()
+			return nil
+		}
+	case tcell.KeyEnter:
+		if s.instanceTable.HasFocus() {
+			s.openInstanceDetail()
+			return nil
+		}
+	case tcell.KeyCtrlD:
+		if s.instanceTable.HasFocus() {
+			if instance, ok := s.selectedInstance(); ok {
+				s.confirmTerminate(instance)
+			}
+			return nil
+		}
+	}
+
+	switch event.Rune() {
+	case 'a':
+		if s.instanceTable.HasFocus() {
+			s.openActions()
+			return nil
+		}
+	case 's':
+		if s.instanceTable.HasFocus() {
+			s.startSSMSession()
+			return nil
+		}
+	case 'h':
+		if s.instanceTable.HasFocus() {
+			s.startSSH()
+			return nil
+		}
+	case 'f':
+		if s.instanceTable.HasFocus() {
+			s.openPortForwardForm()
+			return nil
+		}
+	}
+
+	return event
+}
+
+func (s *Service) exitFilterMode() {
+	s.filter.SetText("")
+	if s.modalVisible() {
+		return
+	}
+	s.focusCurrentTable()
+}
+
+func (s *Service) loadInstances() {
+	s.ctx.SetStatus("Fetching instances...")
+	s.ctx.SetError(nil)
+	s.loadingInstances = true
+
+	go func() {
+		instances, err := awsec2.DescribeInstances()
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.loadingInstances = false
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe instances: %w", err))
+				return
+			}
+			sort.SliceStable(instances, func(i, j int) bool {
+				a, b := awsec2.InstanceName(instances[i]), awsec2.InstanceName(instances[j])
+				if a != b {
+					return a < b
+				}
+				return valueOr(instances[i].InstanceId) < valueOr(instances[j].InstanceId)
+			})
+
+			s.mu.Lock()
+			s.instances = instances
+			s.mu.Unlock()
+			s.applyQuery()
+			s.renderInstances()
+			s.focusCurrentTable()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d instances", len(instances)))
+
+			if s.pendingInstance != "" {
+				if !s.selectPendingInstance() {
+					s.ctx.SetError(fmt.Errorf("instance %s not found", s.pendingInstance))
+				}
+				s.pendingInstance = ""
+			}
+		})
+	}()
+}
+
+func (s *Service) applyFilter(query string) {
+	s.ctx.SetError(nil)
+	s.query = parseInstanceQuery(query)
+	s.applyQuery()
+	s.renderInstances()
+
+	s.filter.SetText("")
+	s.exitFilterMode()
+}
+
+func (s *Service) applyQuery() {
+	s.filteredInstances = s.filteredInstances[:0]
+	for _, instance := range s.instances {
+		if s.query.matches(instance) {
+			s.filteredInstances = append(s.filteredInstances, instance)
+		}
+	}
+}
+
+func (s *Service) renderInstances() {
+	table := s.instanceTable
+	table.Clear()
+
+	title := instanceTableTitle
+	if !s.query.empty() {
+		title += fmt.Sprintf(" (filter: %s)", s.query.raw)
+	}
+	table.SetTitle(title)
+
+	headers := []string{"Name", "Instance ID", "Type", "State", "Private IP", "Public IP", "AZ", "Launched", "Age"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredInstances) == 0 {
+		msg := "No instances found"
+		if len(s.instances) > 0 {
+			msg = "No instances match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	row, _ := table.GetSelection()
+	for idx, instance := range s.filteredInstances {
+		state := instanceState(instance)
+		az := ""
+		if instance.Placement != nil {
+			az = valueOr(instance.Placement.AvailabilityZone)
+		}
+		age := "-"
+		if instance.LaunchTime != nil {
+			age = utils.GetAgeFromTime(instance.LaunchTime)
+		}
+
+		table.SetCell(idx+1, 0, tableCell(awsec2.InstanceName(instance)))
+		table.SetCell(idx+1, 1, tableCell(valueOr(instance.InstanceId)))
+		table.SetCell(idx+1, 2, tableCell(string(instance.InstanceType)))
+		table.SetCell(idx+1, 3, tableCell(string(state)).SetTextColor(stateColor(state)))
+		table.SetCell(idx+1, 4, tableCell(valueOrDash(instance.PrivateIpAddress)))
+		table.SetCell(idx+1, 5, tableCell(valueOrDash(instance.PublicIpAddress)))
+		table.SetCell(idx+1, 6, tableCell(az))
+		table.SetCell(idx+1, 7, tableCell(formatTime(instance.LaunchTime)))
+		table.SetCell(idx+1, 8, tableCell(age))
+	}
+
+	// Keep the cursor in place across refreshes after an action.
+	if row <= 0 || row > len(s.filteredInstances) {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+func (s *Service) selectedInstance() (types.Instance, bool) {
+	row, _ := s.instanceTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredInstances) {
+		return types.Instance{}, false
+	}
+	return s.filteredInstances[row-1], true
+}
+
+// Navigate selects the instance whose ID or Name tag is args[0], loading the
+// instances first when needed.
+func (s *Service) Navigate(args ...string) {
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return
+	}
+	s.closeModal()
+	s.pendingInstance = strings.TrimSpace(args[0])
+	if s.loadingInstances {
+		return
+	}
+	if s.selectPendingInstance() {
+		s.pendingInstance = ""
+		return
+	}
+	s.loadInstances()
+}
+
+// selectPendingInstance clears the filter if needed and selects the pending
+// instance, reporting whether it was found.
+func (s *Service) selectPendingInstance() bool {
+	find := func() int {
+		for idx, instance := range s.filteredInstances {
+			if valueOr(instance.InstanceId) == s.pendingInstance || awsec2.InstanceName(instance) == s.pendingInstance {
+				return idx
+			}
+		}
+		return -1
+	}
+
+	idx := find()
+	if idx < 0 && !s.query.empty() {
+		s.query = instanceQuery{}
+		s.applyQuery()
+		s.renderInstances()
+		idx = find()
+	}
+	if idx < 0 {
+		return false
+	}
+	s.instanceTable.Select(idx+1, 0)
+	s.focusCurrentTable()
+	return true
+}
+
+func instanceState(instance types.Instance) types.InstanceStateName {
+	if instance.State == nil {
+		return ""
+	}
+	return instance.State.Name
+}
+
+func stateColor(state types.InstanceStateName) tcell.Color {
+	switch state {
+	case types.InstanceStateNameRunning:
+		return tcell.ColorGreen
+	case types.InstanceStateNameStopped:
+		return tcell.ColorRed
+	case types.InstanceStateNameTerminated:
+		return tcell.ColorGray
+	}
+	return tcell.ColorYellow
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func headerCell(title string) *tview.TableCell {
+	return tview.NewTableCell(title).
+		SetTextColor(tcell.ColorLightCyan).
+		SetSelectable(false).
+		SetAlign(tview.AlignLeft)
+}
+
+func tableCell(value string) *tview.TableCell {
+	return tview.NewTableCell(value).
+		SetExpansion(1)
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func valueOrDash(ptr *string) string {
+	if ptr == nil || *ptr == "" {
+		return "-"
+	}
+	return *ptr
+}
+
+func buildTable(title string) *tview.Table {
+	tbl := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	tbl.SetBorder(true)
+	tbl.SetTitle(title)
+	tbl.SetBorderColor(tcell.ColorDimGray)
+	return tbl
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
+
+func (s *Service) canFocus() bool {
+	return s.ctx.App != nil && s.active
+}
+
+func (s *Service) setFocus(p tview.Primitive) {
+	if !s.canFocus() || p == nil {
+		return
+	}
+	s.ctx.App.SetFocus(p)
+}
+
+func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
+	s.setFocus(s.instanceTable)
+}
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
@@ -0,0 +1,175 @@
+package ec2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	awsec2 "github.com/jaehong21/hibiscus/internal/aws/ec2"
+	"github.com/jaehong21/hibiscus/internal/shell"
+)
+
+func sessionTarget(instance types.Instance) shell.Target {
+	return shell.Target{
+		InstanceID: valueOr(instance.InstanceId),
+		Name:       awsec2.InstanceName(instance),
+		PrivateIP:  valueOr(instance.PrivateIpAddress),
+		PublicIP:   valueOr(instance.PublicIpAddress),
+	}
+}
+
+// awsOptions points the AWS CLI at the profile and region hibiscus uses.
+func awsOptions() (shell.AWSOptions, error) {
+	region, err := awsec2.Region()
+	if err != nil {
+		return shell.AWSOptions{}, err
+	}
+	cfg := config.GetConfig()
+	return shell.AWSOptions{CLI: cfg.AwsCli, Profile: cfg.AwsProfile, Region: region}, nil
+}
+
+// runningInstance returns the selected instance when sessions can be opened
+// to it.
+func (s *Service) runningInstance() (types.Instance, bool) {
+	instance, ok := s.selectedInstance()
+	if !ok {
+		return types.Instance{}, false
+	}
+	if state := instanceState(instance); state != types.InstanceStateNameRunning {
+		s.ctx.SetStatus(fmt.Sprintf("Instance is %s; sessions need a running instance", state))
+		return types.Instance{}, false
+	}
+	return instance, true
+}
+
+func (s *Service) startSSMSession() {
+	instance, ok := s.runningInstance()
+	if !ok {
+		return
+	}
+	opts, err := awsOptions()
+	if err != nil {
+		s.ctx.SetError(err)
+		return
+	}
+	argv, err := shell.SSMCommand(opts, sessionTarget(instance), nil)
+	if err != nil {
+		s.ctx.SetError(err)
+		return
+	}
+	s.handOff(instanceLabel(instance), argv)
+}
+
+func (s *Service) startSSH() {
+	instance, ok := s.runningInstance()
+	if !ok {
+		return
+	}
+	argv, err := shell.SSHCommand(config.GetConfig().SshCommand, sessionTarget(instance))
+	if err != nil {
+		s.ctx.SetError(fmt.Errorf("ssh command: %w", err))
+		return
+	}
+	s.handOff(instanceLabel(instance), argv)
+}
+
+func (s *Service) openPortForwardForm() {
+	instance, ok := s.runningInstance()
+	if !ok {
+		return
+	}
+
+	remoteHost := tview.NewInputField().
+		SetLabel("Remote host: ").
+		SetPlaceholder("empty forwards to the instance itself")
+	remotePort := tview.NewInputField().
+		SetLabel("Remote port: ").
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	localPort := tview.NewInputField().
+		SetLabel("Local port: ").
+		SetPlaceholder("same as remote port").
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	form := tview.NewForm().
+		AddTextView("Instance", instanceLabel(instance), 0, 1, false, false).
+		AddFormItem(remoteHost).
+		AddFormItem(remotePort).
+		AddFormItem(localPort)
+
+	form.AddButton("Forward", func() {
+		remote, err := strconv.Atoi(strings.TrimSpace(remotePort.GetText()))
+		if err != nil {
+			s.ctx.SetError(fmt.Errorf("remote port is required"))
+			return
+		}
+		local := remote
+		if text := strings.TrimSpace(localPort.GetText()); text != "" {
+			if local, err = strconv.Atoi(text); err != nil {
+				s.ctx.SetError(fmt.Errorf("invalid local port %q", text))
+				return
+			}
+		}
+		opts, err := awsOptions()
+		if err != nil {
+			s.ctx.SetError(err)
+			return
+		}
+		forward := &shell.PortForward{
+			RemoteHost: strings.TrimSpace(remoteHost.GetText()),
+			RemotePort: remote,
+			LocalPort:  local,
+		}
+		argv, err := shell.SSMCommand(opts, sessionTarget(instance), forward)
+		if err != nil {
+			s.ctx.SetError(err)
+			return
+		}
+		s.closeModal()
+		s.handOff(fmt.Sprintf("localhost:%d → %s", local, instanceLabel(instance)), argv)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("SSM port forwarding")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(forwardModalPageName, centerPrimitive(form, 80, 13))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+// handOff suspends the UI, runs argv on the terminal, and resumes once it
+// exits. A failing command keeps its output on screen until Enter is pressed.
+func (s *Service) handOff(label string, argv []string) {
+	if s.ctx.App == nil {
+		return
+	}
+	s.ctx.SetError(nil)
+
+	var runErr error
+	s.ctx.App.Suspend(func() {
+		fmt.Printf("hibiscus: %s\n$ %s\n\n", label, shell.Quote(argv))
+		runErr = shell.Run(argv)
+		if runErr != nil {
+			fmt.Printf("\nhibiscus: %v\nPress Enter to return to hibiscus...", runErr)
+			bufio.NewReader(os.Stdin).ReadString('\n')
+		}
+	})
+
+	if runErr != nil {
+		s.ctx.SetError(fmt.Errorf("%s: %w", argv[0], runErr))
+		return
+	}
+	s.ctx.SetStatus(fmt.Sprintf("Session to %s ended", label))
+}
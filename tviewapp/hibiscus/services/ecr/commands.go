@@ -0,0 +1,122 @@
+package ecr
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+)
+
+// commandAction is one entry of the copy-command menu.
+type commandAction struct {
+	label string
+	run   func()
+}
+
+func (s *Service) openCommandMenu() {
+	actions := []commandAction{}
+
+	if s.imageTable.HasFocus() {
+		if image, ok := s.selectedImage(); ok {
+			ref := s.imageReference(image)
+			digestRef := fmt.Sprintf("%s@%s", s.repoURI(), valueOr(image.ImageDigest))
+			actions = append(actions,
+				commandAction{"docker pull (tag)", func() { s.copyCommand("docker pull", "docker pull "+ref) }},
+				commandAction{"docker pull (digest)", func() { s.copyCommand("docker pull", "docker pull "+digestRef) }},
+				commandAction{"crane copy", func() { s.copyCommand("crane copy", fmt.Sprintf("crane copy %s <destination>", digestRef)) }},
+			)
+		}
+	}
+
+	actions = append(actions,
+		commandAction{"docker login", s.copyDockerLogin},
+		commandAction{"~/.docker/config.json auth entry", s.copyDockerConfigEntry},
+	)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	for idx, action := range actions {
+		run := action.run
+		list.AddItem(action.label, "", rune('1'+idx), func() {
+			s.closeModal()
+			run()
+		})
+	}
+	list.SetDoneFunc(s.closeModal)
+	list.SetBorder(true)
+	list.SetTitle("Copy command")
+	list.SetTitleAlign(tview.AlignLeft)
+
+	s.showModal(commandModalPageName, centerPrimitive(list, 50, len(actions)+2))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(list)
+	}
+}
+
+func (s *Service) repoURI() string {
+	if s.currentRepoURI != "" {
+		return s.currentRepoURI
+	}
+	return s.currentRepo
+}
+
+// imageReference prefers the first tag and falls back to the digest for
+// untagged images.
+func (s *Service) imageReference(image types.ImageDetail) string {
+	if len(image.ImageTags) > 0 && image.ImageTags[0] != "" {
+		return fmt.Sprintf("%s:%s", s.repoURI(), image.ImageTags[0])
+	}
+	return fmt.Sprintf("%s@%s", s.repoURI(), valueOr(image.ImageDigest))
+}
+
+func (s *Service) copyCommand(label, command string) {
+	if err := clipboard.WriteAll(command); err != nil {
+		s.ctx.SetError(fmt.Errorf("failed to copy %s command: %w", label, err))
+		return
+	}
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("%s command copied to clipboard", label))
+}
+
+func (s *Service) copyDockerLogin() {
+	s.withAuthorizationToken(func(auth *ecr.AuthorizationData) {
+		s.copyCommand("docker login", ecr.DockerLoginCommand(auth, config.GetConfig().AwsProfile))
+	})
+}
+
+func (s *Service) copyDockerConfigEntry() {
+	s.withAuthorizationToken(func(auth *ecr.AuthorizationData) {
+		entry, err := ecr.DockerConfigAuthEntry(auth)
+		if err != nil {
+			s.ctx.SetError(fmt.Errorf("render docker config entry: %w", err))
+			return
+		}
+		if err := clipboard.WriteAll(entry); err != nil {
+			s.ctx.SetError(fmt.Errorf("failed to copy docker config entry: %w", err))
+			return
+		}
+		s.ctx.SetError(nil)
+		s.ctx.SetStatus(fmt.Sprintf("Auth entry for %s copied to clipboard", auth.Registry()))
+	})
+}
+
+// withAuthorizationToken fetches a fresh token off the UI goroutine and hands
+// it to fn on the UI goroutine.
+func (s *Service) withAuthorizationToken(fn func(auth *ecr.AuthorizationData)) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus("Fetching ECR authorization token...")
+
+	go func() {
+		auth, err := ecr.GetAuthorizationToken()
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("get authorization token: %w", err))
+				return
+			}
+			fn(auth)
+		})
+	}()
+}
@@ -0,0 +1,188 @@
+package ecr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+func (s *Service) selectedImage() (types.ImageDetail, bool) {
+	if s.currentRepo == "" {
+		return types.ImageDetail{}, false
+	}
+	row, _ := s.imageTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredImages) {
+		return types.ImageDetail{}, false
+	}
+	image := s.filteredImages[row-1]
+	if image.ImageDigest == nil {
+		return types.ImageDetail{}, false
+	}
+	return image, true
+}
+
+func (s *Service) openImageDetail() {
+	image, ok := s.selectedImage()
+	if !ok {
+		return
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching manifest...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Image %s", imageLabel(image)))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(imageDetailModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching manifest for %s...", imageLabel(image)))
+
+	repoName := s.currentRepo
+	digest := *image.ImageDigest
+	go func() {
+		detail, err := ecr.DescribeImageManifest(&repoName, digest)
+		// Replication status is best effort; its error is rendered inline.
+		statuses, replicationErr := ecr.DescribeImageReplicationStatus(&repoName, digest)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("describe image manifest: %w", err))
+				return
+			}
+			view.SetText(formatImageDetail(image, detail, statuses, replicationErr))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded manifest for %s", imageLabel(image)))
+		})
+	}()
+}
+
+func formatImageDetail(image types.ImageDetail, detail *ecr.ImageManifestDetail, replication []types.ImageReplicationStatus, replicationErr error) string {
+	var b strings.Builder
+
+	section(&b, "Image")
+	field(&b, "Digest", detail.Digest)
+	field(&b, "Tags", strings.Join(image.ImageTags, ", "))
+	field(&b, "Media type", detail.Manifest.MediaType)
+	if image.ImageSizeInBytes != nil {
+		field(&b, "Size", utils.GetSizeFromByte(image.ImageSizeInBytes))
+	}
+
+	if detail.Manifest.IsIndex() {
+		section(&b, "Platforms")
+		for _, child := range detail.Manifest.Manifests {
+			fmt.Fprintf(&b, "  [white]%-20s[-] %s  %s\n", child.Platform.String(), child.Digest, formatBytes(child.Size))
+			if manifest, ok := detail.Children[child.Digest]; ok {
+				writeLayers(&b, manifest.Layers, "    ")
+			}
+		}
+	} else {
+		section(&b, "Layers")
+		writeLayers(&b, detail.Manifest.Layers, "  ")
+	}
+
+	if detail.Config != nil || detail.ConfigErr != nil {
+		title := "Config"
+		if detail.ConfigPlatform != nil {
+			title = fmt.Sprintf("Config (%s)", detail.ConfigPlatform.String())
+		}
+		if detail.ConfigErr != nil {
+			section(&b, title)
+			fmt.Fprintf(&b, "  [red]%s[-]\n", tview.Escape(detail.ConfigErr.Error()))
+		} else {
+			writeImageConfig(&b, title, detail.Config)
+		}
+	}
+
+	writeReplicationStatus(&b, replication, replicationErr)
+
+	return b.String()
+}
+
+func writeLayers(b *strings.Builder, layers []ecr.Descriptor, indent string) {
+	if len(layers) == 0 {
+		fmt.Fprintf(b, "%s[gray]no layers[-]\n", indent)
+		return
+	}
+	var total int64
+	for idx, layer := range layers {
+		fmt.Fprintf(b, "%s%2d. %s  %s  [gray]%s[-]\n", indent, idx+1, layer.Digest, formatBytes(layer.Size), layer.MediaType)
+		total += layer.Size
+	}
+	fmt.Fprintf(b, "%s[gray]%d layers, %s compressed[-]\n", indent, len(layers), formatBytes(total))
+}
+
+func writeImageConfig(b *strings.Builder, title string, cfg *ecr.ImageConfig) {
+	section(b, title)
+	if cfg.Created != nil {
+		field(b, "Created", cfg.Created.Local().Format(time.RFC3339))
+	}
+	field(b, "Platform", fmt.Sprintf("%s/%s", cfg.OS, cfg.Architecture))
+	field(b, "Entrypoint", strings.Join(cfg.Config.Entrypoint, " "))
+	field(b, "Cmd", strings.Join(cfg.Config.Cmd, " "))
+	field(b, "Working dir", cfg.Config.WorkingDir)
+	field(b, "User", cfg.Config.User)
+
+	if len(cfg.Config.Env) > 0 {
+		fmt.Fprintf(b, "  [lightcyan]Env[-]\n")
+		for _, env := range cfg.Config.Env {
+			fmt.Fprintf(b, "    %s\n", tview.Escape(env))
+		}
+	}
+
+	if len(cfg.Config.Labels) > 0 {
+		fmt.Fprintf(b, "  [lightcyan]Labels[-]\n")
+		keys := make([]string, 0, len(cfg.Config.Labels))
+		for key := range cfg.Config.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(b, "    %s=%s\n", tview.Escape(key), tview.Escape(cfg.Config.Labels[key]))
+		}
+	}
+}
+
+func section(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "[yellow]%s[-]\n", title)
+}
+
+func field(b *strings.Builder, label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "  [lightcyan]%-12s[-] %s\n", label, tview.Escape(value))
+}
+
+func formatBytes(size int64) string {
+	return utils.GetSizeFromByte(&size)
+}
+
+func imageLabel(image types.ImageDetail) string {
+	if len(image.ImageTags) > 0 {
+		return image.ImageTags[0]
+	}
+	return valueOr(image.ImageDigest)
+}
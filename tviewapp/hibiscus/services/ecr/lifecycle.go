@@ -0,0 +1,238 @@
+package ecr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+const lifecyclePolicyTemplate = `{
+  "rules": [
+    {
+      "rulePriority": 1,
+      "description": "Expire untagged images older than 14 days",
+      "selection": {
+        "tagStatus": "untagged",
+        "countType": "sinceImagePushed",
+        "countUnit": "days",
+        "countNumber": 14
+      },
+      "action": {
+        "type": "expire"
+      }
+    }
+  ]
+}`
+
+// lifecycleRepository resolves the repository the lifecycle action applies
+// to: the highlighted row on the repository table or the open repository on
+// the image table.
+func (s *Service) lifecycleRepository() string {
+	if s.current == imageTab {
+		return s.currentRepo
+	}
+	repo, ok := s.selectedRepository()
+	if !ok {
+		return ""
+	}
+	return valueOr(repo.RepositoryName)
+}
+
+func (s *Service) openLifecyclePolicy() {
+	repo := s.lifecycleRepository()
+	if repo == "" {
+		s.ctx.SetStatus("Select a repository first")
+		return
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching lifecycle policy for %s...", repo))
+
+	repoName := repo
+	go func() {
+		policy, err := ecr.GetLifecyclePolicy(&repoName)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("get lifecycle policy: %w", err))
+				return
+			}
+			status := "Loaded lifecycle policy"
+			if policy == "" {
+				policy = lifecyclePolicyTemplate
+				status = "No lifecycle policy yet – starting from a template"
+			}
+			form := s.buildLifecycleForm(repoName, prettyJSON(policy))
+			s.showModal(lifecycleModalPageName, centerPrimitive(form, 110, 36))
+			if s.ctx.App != nil {
+				s.ctx.App.SetFocus(form)
+			}
+			s.ctx.SetStatus(status)
+		})
+	}()
+}
+
+func (s *Service) buildLifecycleForm(repo, policy string) *tview.Form {
+	editor := tview.NewTextArea().
+		SetLabel("Policy: ").
+		SetSize(16, 0).
+		SetText(policy, false)
+
+	result := tview.NewTextView().
+		SetLabel("Result: ").
+		SetSize(10, 0).
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText("[gray]Validate or preview the policy before saving[-]")
+
+	form := tview.NewForm().
+		AddFormItem(editor).
+		AddFormItem(result)
+
+	form.AddButton("Validate", func() {
+		text := editor.GetText()
+		if err := ecr.ValidateLifecyclePolicy(text); err != nil {
+			result.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		editor.SetText(prettyJSON(text), false)
+		result.SetText("[green]Policy is valid[-]")
+	})
+
+	form.AddButton("Preview", func() {
+		text := editor.GetText()
+		if err := ecr.ValidateLifecyclePolicy(text); err != nil {
+			result.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		result.SetText("[yellow]Running lifecycle policy preview...[-]")
+		s.previewLifecyclePolicy(repo, text, result)
+	})
+
+	form.AddButton("Save", func() {
+		text := editor.GetText()
+		if err := ecr.ValidateLifecyclePolicy(text); err != nil {
+			result.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		s.closeModal()
+		s.saveLifecyclePolicy(repo, text)
+	})
+
+	form.AddButton("Close", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle(fmt.Sprintf("Lifecycle policy – %s", repo))
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	return form
+}
+
+func (s *Service) previewLifecyclePolicy(repo, policy string, result *tview.TextView) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Previewing lifecycle policy for %s...", repo))
+
+	// Only cross-reference the image list when it belongs to this repository.
+	var loaded []types.ImageDetail
+	if repo == s.currentRepo {
+		loaded = append(loaded, s.images...)
+	}
+
+	repoName := repo
+	go func() {
+		results, err := ecr.PreviewLifecyclePolicy(&repoName, policy)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				result.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("preview lifecycle policy: %w", err))
+				return
+			}
+			result.SetText(formatLifecyclePreview(results, loaded))
+			result.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Lifecycle policy would expire %d images", len(results)))
+		})
+	}()
+}
+
+func (s *Service) saveLifecyclePolicy(repo, policy string) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Saving lifecycle policy for %s...", repo))
+
+	repoName := repo
+	go func() {
+		err := ecr.PutLifecyclePolicy(&repoName, policy)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("put lifecycle policy: %w", err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Saved lifecycle policy for %s", repoName))
+		})
+	}()
+}
+
+// formatLifecyclePreview lists the images a preview would expire. Sizes are
+// looked up from the loaded image list since preview results omit them.
+func formatLifecyclePreview(results []types.LifecyclePolicyPreviewResult, loaded []types.ImageDetail) string {
+	if len(results) == 0 {
+		return "[green]No images would expire[-]"
+	}
+
+	byDigest := make(map[string]types.ImageDetail, len(loaded))
+	for _, image := range loaded {
+		byDigest[valueOr(image.ImageDigest)] = image
+	}
+
+	var (
+		b         strings.Builder
+		matched   int
+		reclaimed int64
+	)
+	for _, res := range results {
+		digest := valueOr(res.ImageDigest)
+		tags := "<untagged>"
+		if len(res.ImageTags) > 0 {
+			tags = strings.Join(res.ImageTags, ", ")
+		}
+		pushed := ""
+		if res.ImagePushedAt != nil {
+			pushed = res.ImagePushedAt.Local().Format(time.RFC3339)
+		}
+		size := "-"
+		if image, ok := byDigest[digest]; ok && image.ImageSizeInBytes != nil {
+			size = utils.GetSizeFromByte(image.ImageSizeInBytes)
+			reclaimed += *image.ImageSizeInBytes
+			matched++
+		}
+		priority := "-"
+		if res.AppliedRulePriority != nil {
+			priority = fmt.Sprintf("%d", *res.AppliedRulePriority)
+		}
+		fmt.Fprintf(&b, "[red]expire[-] rule %s  %s  %s  %s  %s\n", priority, tview.Escape(tags), pushed, size, digest)
+	}
+
+	summary := fmt.Sprintf("[yellow]%d images would expire", len(results))
+	if len(loaded) > 0 {
+		summary += fmt.Sprintf(" (%d of %d loaded images, %s)", matched, len(loaded), utils.GetSizeFromByte(&reclaimed))
+	}
+	return summary + "[-]\n" + b.String()
+}
+
+func prettyJSON(text string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(text), "", "  "); err != nil {
+		return text
+	}
+	return buf.String()
+}
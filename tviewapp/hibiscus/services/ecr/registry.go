@@ -0,0 +1,132 @@
+package ecr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+)
+
+func (s *Service) openRegistryView() {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching registry settings...[-]")
+	view.SetBorder(true)
+	view.SetTitle("Registry – replication & pull-through cache")
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(registryModalPageName, centerPrimitive(view, 120, 34))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus("Fetching registry settings...")
+
+	go func() {
+		registry, err := ecr.DescribeRegistry()
+		if err != nil {
+			err = fmt.Errorf("describe registry: %w", err)
+		}
+		var rules []types.PullThroughCacheRule
+		if err == nil {
+			rules, err = ecr.DescribePullThroughCacheRules()
+			if err != nil {
+				err = fmt.Errorf("describe pull through cache rules: %w", err)
+			}
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(err)
+				return
+			}
+			view.SetText(formatRegistry(registry, rules))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus("Loaded registry settings")
+		})
+	}()
+}
+
+func formatRegistry(registry *ecr.Registry, rules []types.PullThroughCacheRule) string {
+	var b strings.Builder
+
+	section(&b, "Registry")
+	field(&b, "Registry ID", registry.RegistryId)
+
+	section(&b, "Replication rules")
+	if registry.Replication == nil || len(registry.Replication.Rules) == 0 {
+		b.WriteString("  [gray]No replication configured[-]\n")
+	} else {
+		for idx, rule := range registry.Replication.Rules {
+			fmt.Fprintf(&b, "  [white]Rule %d[-]\n", idx+1)
+			for _, dest := range rule.Destinations {
+				fmt.Fprintf(&b, "    → %s  [gray]registry %s[-]\n", valueOr(dest.Region), valueOr(dest.RegistryId))
+			}
+			if len(rule.RepositoryFilters) == 0 {
+				b.WriteString("    filter: all repositories\n")
+			}
+			for _, filter := range rule.RepositoryFilters {
+				fmt.Fprintf(&b, "    filter: %s %s\n", filter.FilterType, tview.Escape(valueOr(filter.Filter)))
+			}
+		}
+	}
+
+	section(&b, "Pull-through cache rules")
+	if len(rules) == 0 {
+		b.WriteString("  [gray]No pull-through cache rules[-]\n")
+	}
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "  [white]%s/[-] → %s", tview.Escape(valueOr(rule.EcrRepositoryPrefix)), tview.Escape(valueOr(rule.UpstreamRegistryUrl)))
+		if rule.UpstreamRegistry != "" {
+			fmt.Fprintf(&b, "  [gray](%s)[-]", rule.UpstreamRegistry)
+		}
+		b.WriteString("\n")
+		if rule.CredentialArn != nil {
+			fmt.Fprintf(&b, "    credential: %s\n", *rule.CredentialArn)
+		}
+		if rule.CreatedAt != nil {
+			fmt.Fprintf(&b, "    created: %s\n", rule.CreatedAt.Local().Format(time.RFC3339))
+		}
+	}
+
+	return b.String()
+}
+
+func writeReplicationStatus(b *strings.Builder, statuses []types.ImageReplicationStatus, err error) {
+	section(b, "Replication")
+	if err != nil {
+		fmt.Fprintf(b, "  [red]%s[-]\n", tview.Escape(err.Error()))
+		return
+	}
+	if len(statuses) == 0 {
+		b.WriteString("  [gray]Not replicated[-]\n")
+		return
+	}
+	for _, status := range statuses {
+		color := "yellow"
+		switch status.Status {
+		case types.ReplicationStatusComplete:
+			color = "green"
+		case types.ReplicationStatusFailed:
+			color = "red"
+		}
+		fmt.Fprintf(b, "  %-16s [%s]%s[-]  [gray]registry %s[-]", valueOr(status.Region), color, status.Status, valueOr(status.RegistryId))
+		if status.FailureCode != nil {
+			fmt.Fprintf(b, "  %s", tview.Escape(*status.FailureCode))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,379 @@
+package ecr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+)
+
+var encryptionOptions = []string{
+	string(types.EncryptionTypeAes256),
+	string(types.EncryptionTypeKms),
+}
+
+func (s *Service) selectedRepository() (types.Repository, bool) {
+	row, _ := s.repoTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredRepos) {
+		return types.Repository{}, false
+	}
+	repo := s.filteredRepos[row-1]
+	if repo.RepositoryName == nil {
+		return types.Repository{}, false
+	}
+	return repo, true
+}
+
+func (s *Service) openCreateRepository() {
+	nameInput := tview.NewInputField().
+		SetLabel("Name: ").
+		SetPlaceholder("team/service")
+
+	mutability := tview.NewDropDown().
+		SetLabel("Tag mutability: ").
+		SetOptions([]string{string(types.ImageTagMutabilityMutable), string(types.ImageTagMutabilityImmutable)}, nil).
+		SetCurrentOption(0)
+
+	scanOnPush := tview.NewCheckbox().
+		SetLabel("Scan on push: ")
+
+	encryption := tview.NewDropDown().
+		SetLabel("Encryption: ").
+		SetOptions(encryptionOptions, nil).
+		SetCurrentOption(0)
+
+	kmsKey := tview.NewInputField().
+		SetLabel("KMS key (optional): ").
+		SetPlaceholder("AWS managed key when empty")
+
+	form := tview.NewForm().
+		AddFormItem(nameInput).
+		AddFormItem(mutability).
+		AddFormItem(scanOnPush).
+		AddFormItem(encryption).
+		AddFormItem(kmsKey)
+
+	form.AddButton("Create", func() {
+		name := strings.TrimSpace(nameInput.GetText())
+		if name == "" {
+			s.ctx.SetError(fmt.Errorf("repository name is required"))
+			return
+		}
+		_, mutable := mutability.GetCurrentOption()
+		_, encType := encryption.GetCurrentOption()
+		key := strings.TrimSpace(kmsKey.GetText())
+		if key != "" && encType != string(types.EncryptionTypeKms) {
+			s.ctx.SetError(fmt.Errorf("a KMS key requires KMS encryption"))
+			return
+		}
+
+		s.closeModal()
+		s.createRepository(ecr.RepositorySettings{
+			Name:           name,
+			ImmutableTags:  mutable == string(types.ImageTagMutabilityImmutable),
+			ScanOnPush:     scanOnPush.IsChecked(),
+			EncryptionType: types.EncryptionType(encType),
+			KmsKey:         key,
+		})
+	})
+
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Create repository")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(repoModalPageName, centerPrimitive(form, 80, 15))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) createRepository(settings ecr.RepositorySettings) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Creating repository %s...", settings.Name))
+
+	go func() {
+		_, err := ecr.CreateRepository(settings)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("create repository: %w", err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Created repository %s", settings.Name))
+			s.loadRepos()
+		})
+	}()
+}
+
+func (s *Service) confirmDeleteRepository() {
+	repo, ok := s.selectedRepository()
+	if !ok {
+		s.ctx.SetStatus("Select a repository to delete")
+		return
+	}
+	name := valueOr(repo.RepositoryName)
+
+	force := tview.NewCheckbox().
+		SetLabel("Force (also delete images): ")
+
+	form := tview.NewForm().
+		AddTextView("", fmt.Sprintf("Delete repository %s?", name), 0, 1, false, false).
+		AddFormItem(force)
+
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.AddButton("Delete", func() {
+		s.closeModal()
+		s.deleteRepository(name, force.IsChecked())
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Delete repository")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(repoModalPageName, centerPrimitive(form, 70, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) deleteRepository(name string, force bool) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Deleting repository %s...", name))
+
+	repoName := name
+	go func() {
+		err := ecr.DeleteRepository(&repoName, force)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				var notEmpty *types.RepositoryNotEmptyException
+				if errors.As(err, &notEmpty) {
+					s.ctx.SetError(fmt.Errorf("repository %s still contains images; delete again with force", repoName))
+					return
+				}
+				s.ctx.SetError(fmt.Errorf("delete repository: %w", err))
+				return
+			}
+			if s.currentRepo == repoName {
+				s.currentRepo = ""
+				s.currentRepoURI = ""
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Deleted repository %s", repoName))
+			s.loadRepos()
+		})
+	}()
+}
+
+func (s *Service) openRepositorySettings() {
+	repo, ok := s.selectedRepository()
+	if !ok {
+		s.ctx.SetStatus("Select a repository to edit")
+		return
+	}
+	name := valueOr(repo.RepositoryName)
+	wasImmutable := repo.ImageTagMutability == types.ImageTagMutabilityImmutable
+	wasScanOnPush := repo.ImageScanningConfiguration != nil && repo.ImageScanningConfiguration.ScanOnPush
+
+	immutable := tview.NewCheckbox().
+		SetLabel("Immutable tags: ").
+		SetChecked(wasImmutable)
+
+	scanOnPush := tview.NewCheckbox().
+		SetLabel("Scan on push: ").
+		SetChecked(wasScanOnPush)
+
+	form := tview.NewForm().
+		AddTextView("Encryption: ", formatEncryption(repo.EncryptionConfiguration), 0, 1, false, false).
+		AddFormItem(immutable).
+		AddFormItem(scanOnPush)
+
+	form.AddButton("Save", func() {
+		s.closeModal()
+		s.updateRepositorySettings(name, wasImmutable, immutable.IsChecked(), wasScanOnPush, scanOnPush.IsChecked())
+	})
+
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle(fmt.Sprintf("Settings – %s", name))
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(repoModalPageName, centerPrimitive(form, 70, 11))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+// updateRepositorySettings only calls the APIs whose setting actually changed.
+func (s *Service) updateRepositorySettings(name string, wasImmutable, immutable, wasScanOnPush, scanOnPush bool) {
+	if wasImmutable == immutable && wasScanOnPush == scanOnPush {
+		s.ctx.SetStatus("No changes to save")
+		return
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Updating settings for %s...", name))
+
+	repoName := name
+	go func() {
+		var err error
+		if wasImmutable != immutable {
+			if err = ecr.PutImageTagMutability(&repoName, immutable); err != nil {
+				err = fmt.Errorf("put image tag mutability: %w", err)
+			}
+		}
+		if err == nil && wasScanOnPush != scanOnPush {
+			if err = ecr.PutImageScanningConfiguration(&repoName, scanOnPush); err != nil {
+				err = fmt.Errorf("put image scanning configuration: %w", err)
+			}
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(err)
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Updated settings for %s", repoName))
+			s.loadRepos()
+		})
+	}()
+}
+
+func (s *Service) openRepositoryPolicy() {
+	repo, ok := s.selectedRepository()
+	if !ok {
+		s.ctx.SetStatus("Select a repository first")
+		return
+	}
+	name := valueOr(repo.RepositoryName)
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching repository policy for %s...", name))
+
+	repoName := name
+	go func() {
+		policy, err := ecr.GetRepositoryPolicy(&repoName)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("get repository policy: %w", err))
+				return
+			}
+			form := s.buildRepositoryPolicyForm(repoName, prettyJSON(policy))
+			s.showModal(repoModalPageName, centerPrimitive(form, 100, 26))
+			if s.ctx.App != nil {
+				s.ctx.App.SetFocus(form)
+			}
+			if policy == "" {
+				s.ctx.SetStatus("No repository policy attached")
+				return
+			}
+			s.ctx.SetStatus("Loaded repository policy")
+		})
+	}()
+}
+
+func (s *Service) buildRepositoryPolicyForm(repo, policy string) *tview.Form {
+	editor := tview.NewTextArea().
+		SetLabel("Policy: ").
+		SetSize(18, 0).
+		SetPlaceholder("Paste an IAM policy document").
+		SetText(policy, false)
+
+	form := tview.NewForm().
+		AddFormItem(editor)
+
+	form.AddButton("Save", func() {
+		text := strings.TrimSpace(editor.GetText())
+		if text == "" {
+			s.ctx.SetError(fmt.Errorf("policy is empty; use Remove to detach it"))
+			return
+		}
+		if !json.Valid([]byte(text)) {
+			s.ctx.SetError(fmt.Errorf("policy is not valid JSON"))
+			return
+		}
+		s.closeModal()
+		s.saveRepositoryPolicy(repo, text)
+	})
+
+	if policy != "" {
+		form.AddButton("Remove", func() {
+			s.closeModal()
+			s.removeRepositoryPolicy(repo)
+		})
+	}
+
+	form.AddButton("Close", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle(fmt.Sprintf("Repository policy – %s", repo))
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	return form
+}
+
+func (s *Service) saveRepositoryPolicy(repo, policy string) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Saving repository policy for %s...", repo))
+
+	repoName := repo
+	go func() {
+		err := ecr.SetRepositoryPolicy(&repoName, policy)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("set repository policy: %w", err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Saved repository policy for %s", repoName))
+		})
+	}()
+}
+
+func (s *Service) removeRepositoryPolicy(repo string) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Removing repository policy for %s...", repo))
+
+	repoName := repo
+	go func() {
+		err := ecr.DeleteRepositoryPolicy(&repoName)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("delete repository policy: %w", err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Removed repository policy for %s", repoName))
+		})
+	}()
+}
+
+func formatEncryption(cfg *types.EncryptionConfiguration) string {
+	if cfg == nil || cfg.EncryptionType == "" {
+		return string(types.EncryptionTypeAes256)
+	}
+	if cfg.EncryptionType == types.EncryptionTypeKms && cfg.KmsKey != nil {
+		parts := strings.Split(*cfg.KmsKey, "/")
+		return fmt.Sprintf("KMS (%s)", parts[len(parts)-1])
+	}
+	return string(cfg.EncryptionType)
+}
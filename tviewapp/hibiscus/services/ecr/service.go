@@ -23,10 +23,21 @@ const (
 	imageTab
 )
 
+const (
+	contentPageName          = "ecr-content"
+	lifecycleModalPageName   = "ecr-lifecycle-modal"
+	repoModalPageName        = "ecr-repo-modal"
+	imageDetailModalPageName = "ecr-image-detail-modal"
+	commandModalPageName     = "ecr-command-modal"
+	registryModalPageName    = "ecr-registry-modal"
+	usageModalPageName       = "ecr-usage-modal"
+)
+
 // Service implements the hibiscus.Service interface for Amazon ECR.
 type Service struct {
 	ctx hibiscus.ServiceContext
 
+	root       *tview.Pages
 	layout     *tview.Flex
 	pages      *tview.Pages
 	filter     *tview.InputField
@@ -42,12 +53,22 @@ type Service struct {
 	currentRepo    string
 	currentRepoURI string
 
+	usage        map[string]ecr.RepositoryUsage
+	usageLoading bool
+	usageGen     int
+
 	mu     sync.Mutex
 	active bool
+
+	activeModal string
 }
 
 func New(ctx hibiscus.ServiceContext) hibiscus.Service {
-	svc := &Service{ctx: ctx, current: repoTab}
+	svc := &Service{
+		ctx:     ctx,
+		current: repoTab,
+		usage:   map[string]ecr.RepositoryUsage{},
+	}
 	svc.filter = tview.NewInputField().
 		SetLabel("Filter (/): ").
 		SetFieldBackgroundColor(tcell.ColorBlack)
@@ -63,6 +84,9 @@ func New(ctx hibiscus.ServiceContext) hibiscus.Service {
 		AddItem(svc.filter, 1, 0, true).
 		AddItem(svc.pages, 0, 1, true)
 
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
 	svc.filter.SetDoneFunc(func(key tcell.Key) {
 		switch key {
 		case tcell.KeyEnter:
@@ -78,7 +102,7 @@ func New(ctx hibiscus.ServiceContext) hibiscus.Service {
 func (s *Service) Name() string  { return "ecr" }
 func (s *Service) Title() string { return "Amazon ECR – repositories › images" }
 func (s *Service) Primitive() tview.Primitive {
-	return s.layout
+	return s.root
 }
 
 func (s *Service) Init() {
@@ -103,15 +127,17 @@ func (s *Service) Refresh() {
 }
 
 func (s *Service) EnterFilterMode() bool {
-	if !s.canFocus() {
+	if !s.canFocus() || s.modalVisible() {
 		return false
 	}
 	s.ctx.App.SetFocus(s.filter)
 	return true
 }
 
+// InFilterMode also reports true while a modal is open so typing into its
+// text fields does not trigger global shortcuts.
 func (s *Service) InFilterMode() bool {
-	return s.filter.HasFocus()
+	return s.filter.HasFocus() || s.modalVisible()
 }
 
 func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
@@ -119,6 +145,14 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
 	switch event.Key() {
 	case tcell.KeyEsc:
 		if s.filter.HasFocus() {
@@ -134,6 +168,15 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 			s.openSelectedRepository()
 			return nil
 		}
+		if s.imageTable.HasFocus() {
+			s.openImageDetail()
+			return nil
+		}
+	case tcell.KeyCtrlD:
+		if s.repoTable.HasFocus() {
+			s.confirmDeleteRepository()
+			return nil
+		}
 	}
 
 	switch event.Rune() {
@@ -146,6 +189,41 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 			s.copySelectedImage()
 			return nil
 		}
+	case 'l', 'L':
+		if s.repoTable.HasFocus() || s.imageTable.HasFocus() {
+			s.openLifecyclePolicy()
+			return nil
+		}
+	case 'x', 'X':
+		if s.repoTable.HasFocus() || s.imageTable.HasFocus() {
+			s.openCommandMenu()
+			return nil
+		}
+	case 'u', 'U':
+		if s.repoTable.HasFocus() {
+			s.openUsageReport()
+			return nil
+		}
+	case 'g', 'G':
+		if s.repoTable.HasFocus() {
+			s.openRegistryView()
+			return nil
+		}
+	case 'n', 'N':
+		if s.repoTable.HasFocus() {
+			s.openCreateRepository()
+			return nil
+		}
+	case 'e', 'E':
+		if s.repoTable.HasFocus() {
+			s.openRepositorySettings()
+			return nil
+		}
+	case 'p', 'P':
+		if s.repoTable.HasFocus() {
+			s.openRepositoryPolicy()
+			return nil
+		}
 	}
 
 	return event
@@ -153,6 +231,9 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 
 func (s *Service) exitFilterMode() {
 	s.filter.SetText("")
+	if s.modalVisible() {
+		return
+	}
 	switch s.current {
 	case imageTab:
 		s.setFocus(s.imageTable)
@@ -237,6 +318,14 @@ func (s *Service) loadRepos() {
 			s.renderRepos()
 			s.showRepoTab()
 			s.ctx.SetStatus(fmt.Sprintf("Loaded %d repositories", len(repos)))
+
+			names := make([]string, 0, len(repos))
+			for _, repo := range repos {
+				if repo.RepositoryName != nil {
+					names = append(names, *repo.RepositoryName)
+				}
+			}
+			s.loadUsage(names)
 		})
 	}()
 }
@@ -309,7 +398,7 @@ func (s *Service) renderRepos() {
 	table := s.repoTable
 	table.Clear()
 
-	headers := []string{"Repository", "URI", "Created"}
+	headers := []string{"Repository", "URI", "Tag mutability", "Scan on push", "Encryption", "Created", "Images", "Size", "Oldest push", "Newest push", "Last pull"}
 	for col, title := range headers {
 		table.SetCell(0, col, headerCell(title))
 	}
@@ -319,6 +408,7 @@ func (s *Service) renderRepos() {
 		return
 	}
 
+	row, _ := table.GetSelection()
 	for idx, repo := range s.filteredRepos {
 		name := valueOr(repo.RepositoryName)
 		uri := valueOr(repo.RepositoryUri)
@@ -327,12 +417,28 @@ func (s *Service) renderRepos() {
 			created = repo.CreatedAt.Local().Format(time.RFC3339)
 		}
 
+		mutability := string(repo.ImageTagMutability)
+		scanOnPush := "no"
+		if repo.ImageScanningConfiguration != nil && repo.ImageScanningConfiguration.ScanOnPush {
+			scanOnPush = "yes"
+		}
+
 		table.SetCell(idx+1, 0, tableCell(name))
 		table.SetCell(idx+1, 1, tableCell(uri))
-		table.SetCell(idx+1, 2, tableCell(created))
+		table.SetCell(idx+1, 2, tableCell(mutability))
+		table.SetCell(idx+1, 3, tableCell(scanOnPush))
+		table.SetCell(idx+1, 4, tableCell(formatEncryption(repo.EncryptionConfiguration)))
+		table.SetCell(idx+1, 5, tableCell(created))
+		for col, value := range s.usageCells(name) {
+			table.SetCell(idx+1, 6+col, tableCell(value))
+		}
 	}
 
-	table.Select(1, 0)
+	// Keep the cursor in place when usage columns are filled in later.
+	if row <= 0 || row > len(s.filteredRepos) {
+		row = 1
+	}
+	table.Select(row, 0)
 }
 
 func (s *Service) renderImages() {
@@ -379,14 +485,18 @@ func (s *Service) showRepoTab() {
 	s.current = repoTab
 	s.pages.SwitchToPage("repos")
 	s.repoTable.SetTitle("ECR repositories")
-	s.setFocus(s.repoTable)
+	if !s.modalVisible() {
+		s.setFocus(s.repoTable)
+	}
 }
 
 func (s *Service) showImageTab(repo string) {
 	s.current = imageTab
 	s.pages.SwitchToPage("images")
 	s.imageTable.SetTitle(fmt.Sprintf("Images for %s", repo))
-	s.setFocus(s.imageTable)
+	if !s.modalVisible() {
+		s.setFocus(s.imageTable)
+	}
 }
 
 func matchImage(image types.ImageDetail, query string) bool {
@@ -444,6 +554,9 @@ func (s *Service) setFocus(p tview.Primitive) {
 }
 
 func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
 	switch s.current {
 	case imageTab:
 		s.setFocus(s.imageTable)
@@ -451,3 +564,36 @@ func (s *Service) focusCurrentTable() {
 		s.setFocus(s.repoTable)
 	}
 }
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
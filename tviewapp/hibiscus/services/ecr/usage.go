@@ -0,0 +1,309 @@
+package ecr
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	"github.com/jaehong21/hibiscus/internal/aws/ecr"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+// usageRow is one repository line of the storage report.
+type usageRow struct {
+	name  string
+	usage ecr.RepositoryUsage
+}
+
+// loadUsage scans every repository in the background and replaces the usage
+// columns of the repository table once all scans finish. A newer scan
+// supersedes an older one, whose results are dropped.
+func (s *Service) loadUsage(names []string) {
+	s.mu.Lock()
+	s.usageGen++
+	gen := s.usageGen
+	if len(names) == 0 {
+		s.usage = map[string]ecr.RepositoryUsage{}
+		s.usageLoading = false
+		s.mu.Unlock()
+		return
+	}
+	s.usageLoading = true
+	s.mu.Unlock()
+
+	go func() {
+		var (
+			mu     sync.Mutex
+			usages = make(map[string]ecr.RepositoryUsage, len(names))
+			done   int
+			failed int
+		)
+		ecr.SummarizeRepositories(names, func(name string, usage ecr.RepositoryUsage, err error) {
+			mu.Lock()
+			done++
+			if err != nil {
+				failed++
+			} else {
+				usages[name] = usage
+			}
+			progress := done
+			mu.Unlock()
+
+			s.ctx.App.QueueUpdateDraw(func() {
+				if !s.usageCurrent(gen) {
+					return
+				}
+				s.ctx.SetStatus(fmt.Sprintf("Computing storage usage (%d/%d)...", progress, len(names)))
+			})
+		})
+
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.mu.Lock()
+			if gen != s.usageGen {
+				s.mu.Unlock()
+				return
+			}
+			s.usage = usages
+			s.usageLoading = false
+			s.mu.Unlock()
+			s.renderRepos()
+			if failed > 0 {
+				s.ctx.SetError(fmt.Errorf("storage usage unavailable for %d repositories", failed))
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Computed storage usage for %d repositories", len(names)-failed))
+		})
+	}()
+}
+
+func (s *Service) usageCurrent(gen int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return gen == s.usageGen
+}
+
+// usageCells renders the usage columns for a repository row.
+func (s *Service) usageCells(name string) []string {
+	s.mu.Lock()
+	usage, ok := s.usage[name]
+	loading := s.usageLoading
+	s.mu.Unlock()
+
+	if !ok {
+		placeholder := "-"
+		if loading {
+			placeholder = "…"
+		}
+		return []string{placeholder, placeholder, placeholder, placeholder, placeholder}
+	}
+	return []string{
+		strconv.Itoa(usage.ImageCount),
+		utils.GetSizeFromByte(&usage.TotalBytes),
+		formatDate(usage.OldestPush),
+		formatDate(usage.NewestPush),
+		formatDate(usage.LastPull),
+	}
+}
+
+func (s *Service) usageRows() []usageRow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows := make([]usageRow, 0, len(s.usage))
+	for name, usage := range s.usage {
+		rows = append(rows, usageRow{name: name, usage: usage})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].usage.TotalBytes == rows[j].usage.TotalBytes {
+			return rows[i].name < rows[j].name
+		}
+		return rows[i].usage.TotalBytes > rows[j].usage.TotalBytes
+	})
+	return rows
+}
+
+func (s *Service) openUsageReport() {
+	s.mu.Lock()
+	loading := s.usageLoading
+	s.mu.Unlock()
+	if loading {
+		s.ctx.SetStatus("Storage usage is still being computed")
+		return
+	}
+
+	rows := s.usageRows()
+	if len(rows) == 0 {
+		s.ctx.SetStatus("No storage usage available yet")
+		return
+	}
+
+	price := config.GetConfig().EcrPricePerGB
+	table := buildTable("")
+	renderUsageReport(table, rows, price)
+
+	priceInput := tview.NewInputField().
+		SetLabel("Price per GB-month (USD): ").
+		SetText(strconv.FormatFloat(price, 'f', -1, 64)).
+		SetFieldWidth(10)
+
+	pathInput := tview.NewInputField().
+		SetLabel("CSV path: ").
+		SetText(fmt.Sprintf("ecr-storage-%s.csv", time.Now().Format("20060102-150405")))
+
+	form := tview.NewForm().
+		SetHorizontal(true).
+		AddFormItem(priceInput).
+		AddFormItem(pathInput)
+
+	form.AddButton("Apply price", func() {
+		value, err := strconv.ParseFloat(strings.TrimSpace(priceInput.GetText()), 64)
+		if err != nil || value < 0 {
+			s.ctx.SetError(fmt.Errorf("price must be a non-negative number"))
+			return
+		}
+		price = value
+		config.SetEcrPricePerGB(price)
+		renderUsageReport(table, rows, price)
+		s.ctx.SetError(nil)
+		s.ctx.SetStatus(fmt.Sprintf("Using $%.4f per GB-month", price))
+	})
+
+	form.AddButton("Export CSV", func() {
+		path := strings.TrimSpace(pathInput.GetText())
+		if path == "" {
+			s.ctx.SetError(fmt.Errorf("CSV path is required"))
+			return
+		}
+		if err := exportUsageCSV(path, rows, price); err != nil {
+			s.ctx.SetError(fmt.Errorf("export storage report: %w", err))
+			return
+		}
+		s.ctx.SetError(nil)
+		s.ctx.SetStatus(fmt.Sprintf("Exported storage report to %s", path))
+	})
+
+	form.AddButton("Close", func() {
+		s.closeModal()
+	})
+	form.SetCancelFunc(s.closeModal)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(form, 3, 0, false)
+	layout.SetBorder(true)
+	layout.SetTitle("Storage report – Tab switches between table and controls")
+	layout.SetTitleAlign(tview.AlignLeft)
+
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTab && s.ctx.App != nil {
+			s.ctx.App.SetFocus(form)
+			return nil
+		}
+		return event
+	})
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyBacktab && s.ctx.App != nil {
+			s.ctx.App.SetFocus(table)
+			return nil
+		}
+		return event
+	})
+
+	s.showModal(usageModalPageName, centerPrimitive(layout, 130, 36))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(table)
+	}
+}
+
+func renderUsageReport(table *tview.Table, rows []usageRow, price float64) {
+	table.Clear()
+
+	headers := []string{"Repository", "Images", "Size", "Est. monthly cost", "Oldest push", "Newest push", "Last pull"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	var (
+		totalBytes  int64
+		totalImages int
+	)
+	for idx, row := range rows {
+		cost := ecr.MonthlyStorageCost(row.usage.TotalBytes, price)
+		table.SetCell(idx+1, 0, tableCell(row.name))
+		table.SetCell(idx+1, 1, tableCell(strconv.Itoa(row.usage.ImageCount)))
+		table.SetCell(idx+1, 2, tableCell(utils.GetSizeFromByte(&row.usage.TotalBytes)))
+		table.SetCell(idx+1, 3, tableCell(fmt.Sprintf("$%.2f", cost)))
+		table.SetCell(idx+1, 4, tableCell(formatDate(row.usage.OldestPush)))
+		table.SetCell(idx+1, 5, tableCell(formatDate(row.usage.NewestPush)))
+		table.SetCell(idx+1, 6, tableCell(formatDate(row.usage.LastPull)))
+		totalBytes += row.usage.TotalBytes
+		totalImages += row.usage.ImageCount
+	}
+
+	total := len(rows) + 1
+	table.SetCell(total, 0, tableCell("Total").SetTextColor(tcell.ColorYellow))
+	table.SetCell(total, 1, tableCell(strconv.Itoa(totalImages)).SetTextColor(tcell.ColorYellow))
+	table.SetCell(total, 2, tableCell(utils.GetSizeFromByte(&totalBytes)).SetTextColor(tcell.ColorYellow))
+	table.SetCell(total, 3, tableCell(fmt.Sprintf("$%.2f", ecr.MonthlyStorageCost(totalBytes, price))).SetTextColor(tcell.ColorYellow))
+
+	table.Select(1, 0)
+}
+
+func exportUsageCSV(path string, rows []usageRow, price float64) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{"repository", "images", "size_bytes", "monthly_cost_usd", "oldest_push", "newest_push", "last_pull"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{
+			row.name,
+			strconv.Itoa(row.usage.ImageCount),
+			strconv.FormatInt(row.usage.TotalBytes, 10),
+			strconv.FormatFloat(ecr.MonthlyStorageCost(row.usage.TotalBytes, price), 'f', 4, 64),
+			formatTimestamp(row.usage.OldestPush),
+			formatTimestamp(row.usage.NewestPush),
+			formatTimestamp(row.usage.LastPull),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,266 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/gdamore/tcell/v2"
+
+	classicelb "github.com/jaehong21/hibiscus/internal/aws/elb"
+)
+
+// classicLoadBalancerType is shown in the Type column for Classic Load
+// Balancers, next to the application/network/gateway types of elbv2.
+const classicLoadBalancerType = "classic"
+
+// loadBalancerRow is one line of the load balancer table: either an elbv2
+// load balancer or a Classic one.
+type loadBalancerRow struct {
+	v2      *types.LoadBalancer
+	classic *elbtypes.LoadBalancerDescription
+}
+
+func (r loadBalancerRow) name() string {
+	if r.classic != nil {
+		return valueOr(r.classic.LoadBalancerName)
+	}
+	return valueOr(r.v2.LoadBalancerName)
+}
+
+func (r loadBalancerRow) lbType() string {
+	if r.classic != nil {
+		return classicLoadBalancerType
+	}
+	if r.v2.Type == "" {
+		return "Unknown"
+	}
+	return string(r.v2.Type)
+}
+
+func (r loadBalancerRow) dnsName() string {
+	if r.classic != nil {
+		return valueOr(r.classic.DNSName)
+	}
+	return valueOr(r.v2.DNSName)
+}
+
+// state reports the provisioning state; Classic Load Balancers do not have one.
+func (r loadBalancerRow) state() string {
+	if r.classic != nil {
+		return "-"
+	}
+	if r.v2.State == nil || r.v2.State.Code == "" {
+		return "Unknown"
+	}
+	return string(r.v2.State.Code)
+}
+
+func (r loadBalancerRow) createdTime() *time.Time {
+	if r.classic != nil {
+		return r.classic.CreatedTime
+	}
+	return r.v2.CreatedTime
+}
+
+func loadBalancerRows(v2 []types.LoadBalancer, classic []elbtypes.LoadBalancerDescription) []loadBalancerRow {
+	rows := make([]loadBalancerRow, 0, len(v2)+len(classic))
+	for idx := range v2 {
+		rows = append(rows, loadBalancerRow{v2: &v2[idx]})
+	}
+	for idx := range classic {
+		rows = append(rows, loadBalancerRow{classic: &classic[idx]})
+	}
+	return rows
+}
+
+func (s *Service) openClassicLoadBalancer(lb *elbtypes.LoadBalancerDescription) {
+	s.selectedClassic = lb
+	s.selectedLoadBalancerName = valueOr(lb.LoadBalancerName)
+	s.renderClassicListeners()
+	s.loadInstanceHealth()
+}
+
+func (s *Service) loadInstanceHealth() {
+	if s.selectedClassic == nil {
+		return
+	}
+	s.ctx.SetStatus("Fetching instance health...")
+	s.ctx.SetError(nil)
+
+	name := valueOr(s.selectedClassic.LoadBalancerName)
+	go func() {
+		states, err := classicelb.DescribeInstanceHealth(&name)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe instance health: %w", err))
+				return
+			}
+			s.instanceStates = states
+			s.renderInstanceHealth()
+			s.showClassicTab()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d instances (%s)", len(states), summarizeInstanceHealth(states)))
+		})
+	}()
+}
+
+func (s *Service) renderClassicListeners() {
+	lb := s.selectedClassic
+	s.classicInfo.SetText(formatClassicSummary(lb))
+
+	table := s.classicListenerTable
+	table.Clear()
+
+	headers := []string{"Protocol", "Port", "Instance protocol", "Instance port", "SSL certificate", "Policies"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(lb.ListenerDescriptions) == 0 {
+		table.SetCell(1, 0, tableCell("No listeners found").SetSelectable(false))
+		return
+	}
+
+	for idx, desc := range lb.ListenerDescriptions {
+		protocol, port, instanceProtocol, instancePort, certificate := "-", "-", "-", "-", "-"
+		if listener := desc.Listener; listener != nil {
+			protocol = valueOr(listener.Protocol)
+			port = fmt.Sprintf("%d", listener.LoadBalancerPort)
+			instanceProtocol = valueOr(listener.InstanceProtocol)
+			if instanceProtocol == "" {
+				instanceProtocol = protocol
+			}
+			if listener.InstancePort != nil {
+				instancePort = fmt.Sprintf("%d", *listener.InstancePort)
+			}
+			if listener.SSLCertificateId != nil {
+				certificate = *listener.SSLCertificateId
+			}
+		}
+		policies := "-"
+		if len(desc.PolicyNames) > 0 {
+			policies = strings.Join(desc.PolicyNames, ", ")
+		}
+
+		table.SetCell(idx+1, 0, tableCell(protocol))
+		table.SetCell(idx+1, 1, tableCell(port))
+		table.SetCell(idx+1, 2, tableCell(instanceProtocol))
+		table.SetCell(idx+1, 3, tableCell(instancePort))
+		table.SetCell(idx+1, 4, tableCell(certificate))
+		table.SetCell(idx+1, 5, tableCell(policies))
+	}
+
+	table.Select(1, 0)
+}
+
+func (s *Service) renderInstanceHealth() {
+	table := s.classicInstanceTable
+	table.Clear()
+
+	headers := []string{"Instance", "State", "Reason", "Description"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.instanceStates) == 0 {
+		table.SetCell(1, 0, tableCell("No registered instances").SetSelectable(false))
+		return
+	}
+
+	for idx, state := range s.instanceStates {
+		reason := valueOr(state.ReasonCode)
+		if reason == "N/A" {
+			reason = ""
+		}
+		description := valueOr(state.Description)
+		if description == "N/A" {
+			description = ""
+		}
+		table.SetCell(idx+1, 0, tableCell(valueOr(state.InstanceId)))
+		table.SetCell(idx+1, 1, tableCell(valueOr(state.State)).SetTextColor(instanceStateColor(valueOr(state.State))))
+		table.SetCell(idx+1, 2, tableCell(reason))
+		table.SetCell(idx+1, 3, tableCell(description))
+	}
+
+	table.Select(1, 0)
+}
+
+func (s *Service) showClassicTab() {
+	s.current = classicTab
+	s.classicListenerTable.SetTitle(fmt.Sprintf("Listeners for %s (classic)", s.selectedLoadBalancerName))
+	s.classicInstanceTable.SetTitle(fmt.Sprintf("Instance health for %s – Tab switches tables", s.selectedLoadBalancerName))
+	s.pages.SwitchToPage("classic")
+	s.setFocus(s.classicInstanceTable)
+}
+
+// toggleClassicFocus moves focus between the listener and instance tables of
+// the Classic Load Balancer view.
+func (s *Service) toggleClassicFocus() {
+	if s.classicInstanceTable.HasFocus() {
+		s.setFocus(s.classicListenerTable)
+		return
+	}
+	s.setFocus(s.classicInstanceTable)
+}
+
+func formatClassicSummary(lb *elbtypes.LoadBalancerDescription) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[lightcyan]Scheme[-] %s  [lightcyan]VPC[-] %s  [lightcyan]AZs[-] %s\n",
+		valueOr(lb.Scheme), valueOr(lb.VPCId), strings.Join(lb.AvailabilityZones, ", "))
+
+	check := lb.HealthCheck
+	if check == nil {
+		b.WriteString("[lightcyan]Health check[-] not configured")
+		return b.String()
+	}
+	interval, timeout := "-", "-"
+	if check.Interval != nil {
+		interval = fmt.Sprintf("%ds", *check.Interval)
+	}
+	if check.Timeout != nil {
+		timeout = fmt.Sprintf("%ds", *check.Timeout)
+	}
+	healthy, unhealthy := "-", "-"
+	if check.HealthyThreshold != nil {
+		healthy = fmt.Sprintf("%d", *check.HealthyThreshold)
+	}
+	if check.UnhealthyThreshold != nil {
+		unhealthy = fmt.Sprintf("%d", *check.UnhealthyThreshold)
+	}
+	fmt.Fprintf(&b, "[lightcyan]Health check[-] %s  every %s, timeout %s, healthy after %s, unhealthy after %s",
+		valueOr(check.Target), interval, timeout, healthy, unhealthy)
+	return b.String()
+}
+
+func instanceStateColor(state string) tcell.Color {
+	switch state {
+	case "InService":
+		return tcell.ColorGreen
+	case "OutOfService":
+		return tcell.ColorRed
+	default:
+		return tcell.ColorYellow
+	}
+}
+
+func summarizeInstanceHealth(states []elbtypes.InstanceState) string {
+	counts := map[string]int{}
+	var order []string
+	for _, state := range states {
+		key := valueOr(state.State)
+		if _, ok := counts[key]; !ok {
+			order = append(order, key)
+		}
+		counts[key]++
+	}
+	if len(order) == 0 {
+		return "no health data"
+	}
+	parts := make([]string, 0, len(order))
+	for _, key := range order {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[key], key))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,251 @@
+package elb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+)
+
+// describeCondition renders a rule condition as its field name and a
+// human-readable value covering every condition config type.
+func describeCondition(cond types.RuleCondition) (string, string) {
+	field := valueOr(cond.Field)
+	var values []string
+	switch {
+	case cond.HostHeaderConfig != nil:
+		values = cond.HostHeaderConfig.Values
+	case cond.PathPatternConfig != nil:
+		values = cond.PathPatternConfig.Values
+	case cond.HttpHeaderConfig != nil:
+		header := valueOr(cond.HttpHeaderConfig.HttpHeaderName)
+		return field, fmt.Sprintf("%s: %s", header, strings.Join(cond.HttpHeaderConfig.Values, ", "))
+	case cond.HttpRequestMethodConfig != nil:
+		values = cond.HttpRequestMethodConfig.Values
+	case cond.QueryStringConfig != nil:
+		return field, elbv2.FormatQueryStrings(cond.QueryStringConfig.Values)
+	case cond.SourceIpConfig != nil:
+		values = cond.SourceIpConfig.Values
+	default:
+		values = cond.Values
+	}
+	if len(values) == 0 {
+		return field, "-"
+	}
+	return field, strings.Join(values, ", ")
+}
+
+// describeAction renders one action, including authenticate actions.
+func describeAction(action types.Action) string {
+	switch action.Type {
+	case types.ActionTypeEnumForward:
+		if tuples := forwardedTargetGroups([]types.Action{action}); len(tuples) > 0 {
+			return fmt.Sprintf("forward → %s", elbv2.FormatTargetGroups(tuples))
+		}
+		return "forward"
+	case types.ActionTypeEnumRedirect:
+		if cfg := action.RedirectConfig; cfg != nil {
+			redirect := elbv2.ActionSpec{
+				RedirectProtocol: valueOr(cfg.Protocol),
+				RedirectHost:     valueOr(cfg.Host),
+				RedirectPort:     valueOr(cfg.Port),
+				RedirectPath:     valueOr(cfg.Path),
+				RedirectQuery:    valueOr(cfg.Query),
+			}
+			return fmt.Sprintf("redirect %s → %s", cfg.StatusCode, redirect.RedirectTarget())
+		}
+		return "redirect"
+	case types.ActionTypeEnumFixedResponse:
+		if cfg := action.FixedResponseConfig; cfg != nil {
+			response := fmt.Sprintf("fixed-response %s", valueOr(cfg.StatusCode))
+			if cfg.ContentType != nil {
+				response += " " + *cfg.ContentType
+			}
+			return response
+		}
+		return "fixed-response"
+	case types.ActionTypeEnumAuthenticateOidc:
+		if cfg := action.AuthenticateOidcConfig; cfg != nil {
+			return fmt.Sprintf("authenticate-oidc %s (client %s, %s)", valueOr(cfg.Issuer), valueOr(cfg.ClientId), unauthenticatedBehavior(string(cfg.OnUnauthenticatedRequest)))
+		}
+		return "authenticate-oidc"
+	case types.ActionTypeEnumAuthenticateCognito:
+		if cfg := action.AuthenticateCognitoConfig; cfg != nil {
+			return fmt.Sprintf("authenticate-cognito %s (pool %s, %s)", valueOr(cfg.UserPoolDomain), cognitoPoolID(valueOr(cfg.UserPoolArn)), unauthenticatedBehavior(string(cfg.OnUnauthenticatedRequest)))
+		}
+		return "authenticate-cognito"
+	default:
+		return string(action.Type)
+	}
+}
+
+func unauthenticatedBehavior(behavior string) string {
+	if behavior == "" {
+		return "authenticate"
+	}
+	return behavior
+}
+
+func cognitoPoolID(arn string) string {
+	if _, id, ok := strings.Cut(arn, ":userpool/"); ok {
+		return id
+	}
+	return arn
+}
+
+// orderedActions returns actions in evaluation order.
+func orderedActions(actions []types.Action) []types.Action {
+	ordered := append([]types.Action(nil), actions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return actionOrder(ordered[i]) < actionOrder(ordered[j])
+	})
+	return ordered
+}
+
+func actionOrder(action types.Action) int32 {
+	if action.Order == nil {
+		return 0
+	}
+	return *action.Order
+}
+
+func (s *Service) openRuleDetail() {
+	rule, ok := s.selectedRule()
+	if !ok {
+		return
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(formatRuleDetail(rule))
+	view.SetBorder(true)
+	title := "Default rule"
+	if !isDefaultRule(rule) {
+		title = fmt.Sprintf("Rule %s", valueOr(rule.Priority))
+	}
+	view.SetTitle(title)
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(ruleDetailModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+}
+
+func formatRuleDetail(rule types.Rule) string {
+	var b strings.Builder
+
+	section(&b, "Rule")
+	priority := valueOr(rule.Priority)
+	if isDefaultRule(rule) {
+		priority = "default"
+	}
+	field(&b, "Priority", priority)
+	field(&b, "ARN", valueOr(rule.RuleArn))
+
+	section(&b, "Conditions")
+	if len(rule.Conditions) == 0 {
+		b.WriteString("  [gray]none (matches every request)[-]\n")
+	}
+	for _, cond := range rule.Conditions {
+		kind, value := describeCondition(cond)
+		field(&b, kind, value)
+	}
+
+	section(&b, "Actions")
+	for idx, action := range orderedActions(rule.Actions) {
+		fmt.Fprintf(&b, "  %d. %s\n", idx+1, tview.Escape(describeAction(action)))
+	}
+
+	section(&b, "JSON")
+	data, err := ruleJSON(rule)
+	if err != nil {
+		fmt.Fprintf(&b, "  [red]%s[-]\n", tview.Escape(err.Error()))
+	} else {
+		b.WriteString(tview.Escape(data))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ruleJSON renders the rule like the AWS CLI does, omitting unset fields.
+func ruleJSON(rule types.Rule) (string, error) {
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return "", err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", err
+	}
+	pretty, err := json.MarshalIndent(pruneEmpty(doc), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(pretty), nil
+}
+
+func pruneEmpty(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			item = pruneEmpty(item)
+			if isEmptyJSON(item) {
+				delete(v, key)
+				continue
+			}
+			v[key] = item
+		}
+		return v
+	case []any:
+		for idx, item := range v {
+			v[idx] = pruneEmpty(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isEmptyJSON(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func section(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "[yellow]%s[-]\n", title)
+}
+
+func field(b *strings.Builder, label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "  [lightcyan]%-14s[-] %s\n", label, tview.Escape(value))
+}
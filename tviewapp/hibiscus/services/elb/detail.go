@@ -0,0 +1,274 @@
+package elb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/acm"
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+)
+
+// Certificates expiring within these windows are highlighted.
+const (
+	certificateCriticalWindow = 14 * 24 * time.Hour
+	certificateWarningWindow  = 30 * 24 * time.Hour
+)
+
+// listenerCertificates is the TLS configuration of one HTTPS/TLS listener.
+type listenerCertificates struct {
+	listener     types.Listener
+	certificates []listenerCertificate
+	err          error
+}
+
+type listenerCertificate struct {
+	certificate types.Certificate
+	detail      *acmtypes.CertificateDetail
+	err         error
+}
+
+// loadBalancerDetail gathers everything shown in the load balancer pane.
+// Listener and certificate errors are kept per entry and rendered inline.
+type loadBalancerDetail struct {
+	attributes   []types.LoadBalancerAttribute
+	listeners    []listenerCertificates
+	listenersErr error
+}
+
+func (s *Service) selectedLoadBalancer() (loadBalancerRow, bool) {
+	row, _ := s.lbTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredLoadBalancers) {
+		return loadBalancerRow{}, false
+	}
+	return s.filteredLoadBalancers[row-1], true
+}
+
+func (s *Service) openLoadBalancerDetail() {
+	row, ok := s.selectedLoadBalancer()
+	if !ok {
+		return
+	}
+	if row.classic != nil {
+		s.ctx.SetStatus("Press Enter on a classic load balancer for its listeners, health check and instance health")
+		return
+	}
+	lb := *row.v2
+	if lb.LoadBalancerArn == nil {
+		return
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching load balancer attributes...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Load balancer %s", valueOr(lb.LoadBalancerName)))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(lbDetailModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching details for %s...", valueOr(lb.LoadBalancerName)))
+
+	go func() {
+		detail, err := describeLoadBalancerDetail(lb)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("describe load balancer attributes: %w", err))
+				return
+			}
+			view.SetText(formatLoadBalancerDetail(lb, detail, time.Now()))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded details for %s", valueOr(lb.LoadBalancerName)))
+		})
+	}()
+}
+
+func describeLoadBalancerDetail(lb types.LoadBalancer) (*loadBalancerDetail, error) {
+	attributes, err := elbv2.DescribeLoadBalancerAttributes(lb.LoadBalancerArn)
+	if err != nil {
+		return nil, err
+	}
+	detail := &loadBalancerDetail{attributes: attributes}
+
+	listeners, err := elbv2.DescribeListeners(lb.LoadBalancerArn)
+	if err != nil {
+		detail.listenersErr = err
+		return detail, nil
+	}
+
+	// ACM lookups are shared between listeners that reuse a certificate.
+	certDetails := map[string]listenerCertificate{}
+	for _, listener := range listeners {
+		if listener.Protocol != types.ProtocolEnumHttps && listener.Protocol != types.ProtocolEnumTls {
+			continue
+		}
+		entry := listenerCertificates{listener: listener}
+		certificates, err := elbv2.DescribeListenerCertificates(listener.ListenerArn)
+		if err != nil {
+			entry.err = err
+			detail.listeners = append(detail.listeners, entry)
+			continue
+		}
+		for _, certificate := range certificates {
+			arn := valueOr(certificate.CertificateArn)
+			cached, ok := certDetails[arn]
+			if !ok {
+				cached = listenerCertificate{}
+				if strings.Contains(arn, ":acm:") {
+					cached.detail, cached.err = acm.DescribeCertificate(certificate.CertificateArn)
+				}
+				certDetails[arn] = cached
+			}
+			cached.certificate = certificate
+			entry.certificates = append(entry.certificates, cached)
+		}
+		detail.listeners = append(detail.listeners, entry)
+	}
+
+	return detail, nil
+}
+
+func formatLoadBalancerDetail(lb types.LoadBalancer, detail *loadBalancerDetail, now time.Time) string {
+	var b strings.Builder
+
+	section(&b, "Load balancer")
+	field(&b, "Name", valueOr(lb.LoadBalancerName))
+	field(&b, "ARN", valueOr(lb.LoadBalancerArn))
+	field(&b, "Type", string(lb.Type))
+	field(&b, "Scheme", string(lb.Scheme))
+	field(&b, "IP type", string(lb.IpAddressType))
+	field(&b, "VPC", valueOr(lb.VpcId))
+	field(&b, "DNS name", valueOr(lb.DNSName))
+	field(&b, "Hosted zone", valueOr(lb.CanonicalHostedZoneId))
+	if lb.State != nil {
+		state := string(lb.State.Code)
+		if lb.State.Reason != nil {
+			state = fmt.Sprintf("%s (%s)", state, *lb.State.Reason)
+		}
+		field(&b, "State", state)
+	}
+
+	section(&b, "Availability zones")
+	if len(lb.AvailabilityZones) == 0 {
+		b.WriteString("  [gray]none[-]\n")
+	}
+	for _, az := range lb.AvailabilityZones {
+		line := fmt.Sprintf("  %-16s %s", valueOr(az.ZoneName), valueOr(az.SubnetId))
+		var addresses []string
+		for _, address := range az.LoadBalancerAddresses {
+			switch {
+			case address.IpAddress != nil:
+				addresses = append(addresses, *address.IpAddress)
+			case address.PrivateIPv4Address != nil:
+				addresses = append(addresses, *address.PrivateIPv4Address)
+			}
+		}
+		if len(addresses) > 0 {
+			line += fmt.Sprintf("  [gray]%s[-]", strings.Join(addresses, ", "))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	section(&b, "Security groups")
+	if len(lb.SecurityGroups) == 0 {
+		b.WriteString("  [gray]none[-]\n")
+	}
+	for _, group := range lb.SecurityGroups {
+		fmt.Fprintf(&b, "  %s\n", group)
+	}
+
+	section(&b, "Attributes")
+	attributes := append([]types.LoadBalancerAttribute(nil), detail.attributes...)
+	sort.Slice(attributes, func(i, j int) bool {
+		return valueOr(attributes[i].Key) < valueOr(attributes[j].Key)
+	})
+	for _, attribute := range attributes {
+		fmt.Fprintf(&b, "  [lightcyan]%-52s[-] %s\n", tview.Escape(valueOr(attribute.Key)), tview.Escape(valueOr(attribute.Value)))
+	}
+
+	section(&b, "TLS listeners")
+	switch {
+	case detail.listenersErr != nil:
+		fmt.Fprintf(&b, "  [red]%s[-]\n", tview.Escape(detail.listenersErr.Error()))
+	case len(detail.listeners) == 0:
+		b.WriteString("  [gray]No HTTPS or TLS listeners[-]\n")
+	}
+	for _, entry := range detail.listeners {
+		port := ""
+		if entry.listener.Port != nil {
+			port = fmt.Sprintf(":%d", *entry.listener.Port)
+		}
+		fmt.Fprintf(&b, "  [white]%s%s[-]  policy %s\n", entry.listener.Protocol, port, valueOr(entry.listener.SslPolicy))
+		if entry.err != nil {
+			fmt.Fprintf(&b, "    [red]%s[-]\n", tview.Escape(entry.err.Error()))
+			continue
+		}
+		for _, cert := range entry.certificates {
+			writeListenerCertificate(&b, cert, now)
+		}
+	}
+
+	return b.String()
+}
+
+func writeListenerCertificate(b *strings.Builder, cert listenerCertificate, now time.Time) {
+	arn := valueOr(cert.certificate.CertificateArn)
+	label := arn
+	if cert.detail != nil && cert.detail.DomainName != nil {
+		label = *cert.detail.DomainName
+	}
+	if cert.certificate.IsDefault != nil && *cert.certificate.IsDefault {
+		label += " (default)"
+	}
+	fmt.Fprintf(b, "    %s\n", tview.Escape(label))
+
+	switch {
+	case cert.err != nil:
+		fmt.Fprintf(b, "      [red]%s[-]\n", tview.Escape(cert.err.Error()))
+	case cert.detail == nil:
+		fmt.Fprintf(b, "      [gray]%s – expiry only available for ACM certificates[-]\n", tview.Escape(arn))
+	default:
+		fmt.Fprintf(b, "      [gray]%s[-]\n", tview.Escape(arn))
+		if len(cert.detail.SubjectAlternativeNames) > 0 {
+			fmt.Fprintf(b, "      SANs: %s\n", tview.Escape(strings.Join(cert.detail.SubjectAlternativeNames, ", ")))
+		}
+		fmt.Fprintf(b, "      Status: %s  Expires: %s\n", cert.detail.Status, formatCertificateExpiry(cert.detail.NotAfter, now))
+	}
+}
+
+func formatCertificateExpiry(notAfter *time.Time, now time.Time) string {
+	if notAfter == nil {
+		return "-"
+	}
+	remaining := notAfter.Sub(now)
+	date := notAfter.Local().Format("2006-01-02")
+	days := int(remaining.Hours() / 24)
+	switch {
+	case remaining <= 0:
+		return fmt.Sprintf("[red]%s (expired)[-]", date)
+	case remaining <= certificateCriticalWindow:
+		return fmt.Sprintf("[red]%s (in %d days)[-]", date, days)
+	case remaining <= certificateWarningWindow:
+		return fmt.Sprintf("[yellow]%s (in %d days)[-]", date, days)
+	default:
+		return fmt.Sprintf("[green]%s (in %d days)[-]", date, days)
+	}
+}
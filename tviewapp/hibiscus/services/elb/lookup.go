@@ -0,0 +1,168 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awsr53 "github.com/jaehong21/hibiscus/internal/aws/route53"
+)
+
+// openDNSReferences lists the Route53 records that point at the selected
+// load balancer. Press r inside the pane to rescan without the cache and
+// Enter to open the record in the Route53 view.
+func (s *Service) openDNSReferences() {
+	row, ok := s.selectedLoadBalancer()
+	if !ok {
+		return
+	}
+	dnsName := row.dnsName()
+	if dnsName == "" {
+		s.ctx.SetStatus("This load balancer has no DNS name")
+		return
+	}
+
+	table := buildTable(fmt.Sprintf("Records pointing at %s – Enter opens, r rescans", row.name()))
+	table.SetCell(0, 0, tableCell("Scanning hosted zones...").SetSelectable(false))
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'r' || event.Rune() == 'R' {
+			s.scanDNSReferences(table, row.name(), dnsName, true)
+			return nil
+		}
+		return event
+	})
+
+	s.showModal(dnsModalPageName, centerPrimitive(table, 120, 20))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(table)
+	}
+	s.scanDNSReferences(table, row.name(), dnsName, false)
+}
+
+func (s *Service) scanDNSReferences(table *tview.Table, name, dnsName string, refresh bool) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Scanning hosted zones for %s...", dnsName))
+
+	go func() {
+		references, err := awsr53.FindRecordsPointingTo(dnsName, refresh)
+		s.ctx.App.QueueUpdateDraw(func() {
+			renderDNSReferences(table, references)
+			table.SetSelectedFunc(func(row, _ int) {
+				if row <= 0 || row-1 >= len(references) {
+					return
+				}
+				ref := references[row-1]
+				s.closeModal()
+				if s.ctx.Navigate != nil {
+					s.ctx.Navigate("route53", ref.ZoneID, valueOr(ref.Record.Name))
+				}
+			})
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("scan hosted zones: %w", err))
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Found %d records pointing at %s", len(references), name))
+		})
+	}()
+}
+
+func renderDNSReferences(table *tview.Table, references []awsr53.RecordReference) {
+	table.Clear()
+
+	headers := []string{"Hosted zone", "Record name", "Type", "Via", "Routing"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(references) == 0 {
+		table.SetCell(1, 0, tableCell("No alias or CNAME records point here").SetSelectable(false))
+		return
+	}
+
+	for idx, ref := range references {
+		table.SetCell(idx+1, 0, tableCell(fmt.Sprintf("%s (%s)", ref.ZoneName, ref.ZoneID)))
+		table.SetCell(idx+1, 1, tableCell(strings.TrimSuffix(valueOr(ref.Record.Name), ".")))
+		table.SetCell(idx+1, 2, tableCell(string(ref.Record.Type)))
+		table.SetCell(idx+1, 3, tableCell(ref.Via))
+		table.SetCell(idx+1, 4, tableCell(describeRouting(ref.Record)))
+	}
+
+	table.Select(1, 0)
+}
+
+// describeRouting summarizes the routing policy of a record set.
+func describeRouting(record r53types.ResourceRecordSet) string {
+	var parts []string
+	if record.Weight != nil {
+		parts = append(parts, fmt.Sprintf("weight %d", *record.Weight))
+	}
+	if record.Region != "" {
+		parts = append(parts, fmt.Sprintf("latency %s", record.Region))
+	}
+	if record.Failover != "" {
+		parts = append(parts, fmt.Sprintf("failover %s", record.Failover))
+	}
+	if record.SetIdentifier != nil {
+		parts = append(parts, fmt.Sprintf("id %s", *record.SetIdentifier))
+	}
+	if len(parts) == 0 {
+		return "simple"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Navigate selects the load balancer identified by args, which may be its
+// ARN, name or DNS name. Arguments are joined with "/" so an ARN split by a
+// deep link path is reassembled. Load balancers are reloaded when it is not
+// listed yet.
+func (s *Service) Navigate(args ...string) {
+	key := strings.TrimSpace(strings.Join(args, "/"))
+	if key == "" {
+		return
+	}
+	s.closeModal()
+	s.pendingLoadBalancer = key
+	if s.selectPendingLoadBalancer() || s.loadingLoadBalancers {
+		return
+	}
+	s.loadLoadBalancers()
+}
+
+// selectPendingLoadBalancer shows and selects the load balancer requested by
+// Navigate. It reports false when it is not among the loaded ones.
+func (s *Service) selectPendingLoadBalancer() bool {
+	if s.pendingLoadBalancer == "" {
+		return false
+	}
+	for idx, lb := range s.loadBalancers {
+		if !lb.matches(s.pendingLoadBalancer) {
+			continue
+		}
+		s.pendingLoadBalancer = ""
+		s.filteredLoadBalancers = append(s.filteredLoadBalancers[:0], s.loadBalancers...)
+		s.renderLoadBalancers()
+		s.showLoadBalancerTab()
+		s.lbTable.Select(idx+1, 0)
+		s.ctx.SetStatus(fmt.Sprintf("Selected %s", lb.name()))
+		return true
+	}
+	return false
+}
+
+// matches reports whether key is the ARN, name or DNS name of the row.
+func (r loadBalancerRow) matches(key string) bool {
+	if r.v2 != nil && valueOr(r.v2.LoadBalancerArn) == key {
+		return true
+	}
+	if r.name() == key {
+		return true
+	}
+	return awsr53.NormalizeDNSName(r.dnsName()) == awsr53.NormalizeDNSName(key)
+}
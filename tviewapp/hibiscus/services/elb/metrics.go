@@ -0,0 +1,609 @@
+package elb
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/cloudwatch"
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+const (
+	// The table columns cover the last hour in five-minute periods.
+	tableMetricsWindow = time.Hour
+	tableMetricsPeriod = 5 * time.Minute
+	chartHeight        = 12
+)
+
+var metricHeaders = []string{"Requests (1h)", "5xx rate", "Response time"}
+
+// metricSource says where a load balancer or target group publishes its
+// metrics. Network and gateway load balancers have no request metrics and
+// get no source.
+type metricSource struct {
+	namespace  string
+	dimensions []cloudwatch.Dimension
+	requests   string
+	// errors are the 5xx count metrics, summed.
+	errors []string
+	// latency is reported in seconds.
+	latency string
+}
+
+// trafficMetrics are the table series of one row; unsupported rows have
+// none.
+type trafficMetrics struct {
+	requests cloudwatch.Series
+	errors   cloudwatch.Series
+	latency  cloudwatch.Series
+}
+
+func lbMetricSource(row loadBalancerRow) (metricSource, bool) {
+	if row.classic != nil {
+		return metricSource{
+			namespace:  "AWS/ELB",
+			dimensions: []cloudwatch.Dimension{{Name: "LoadBalancerName", Value: row.name()}},
+			requests:   "RequestCount",
+			errors:     []string{"HTTPCode_ELB_5XX", "HTTPCode_Backend_5XX"},
+			latency:    "Latency",
+		}, true
+	}
+	if row.lbType() != "application" {
+		return metricSource{}, false
+	}
+	dimension, ok := arnResource(valueOr(row.v2.LoadBalancerArn), ":loadbalancer/")
+	if !ok {
+		return metricSource{}, false
+	}
+	return metricSource{
+		namespace:  "AWS/ApplicationELB",
+		dimensions: []cloudwatch.Dimension{{Name: "LoadBalancer", Value: dimension}},
+		requests:   "RequestCount",
+		errors:     []string{"HTTPCode_ELB_5XX_Count", "HTTPCode_Target_5XX_Count"},
+		latency:    "TargetResponseTime",
+	}, true
+}
+
+// targetGroupMetricSource covers target groups of application load
+// balancers, whose metrics are published per load balancer.
+func targetGroupMetricSource(loadBalancerArn, targetGroupArn string) (metricSource, bool) {
+	lb, ok := arnResource(loadBalancerArn, ":loadbalancer/")
+	if !ok || !strings.HasPrefix(lb, "app/") {
+		return metricSource{}, false
+	}
+	group, ok := arnResource(targetGroupArn, ":targetgroup/")
+	if !ok {
+		return metricSource{}, false
+	}
+	return metricSource{
+		namespace: "AWS/ApplicationELB",
+		dimensions: []cloudwatch.Dimension{
+			{Name: "TargetGroup", Value: "targetgroup/" + group},
+			{Name: "LoadBalancer", Value: lb},
+		},
+		requests: "RequestCount",
+		errors:   []string{"HTTPCode_Target_5XX_Count"},
+		latency:  "TargetResponseTime",
+	}, true
+}
+
+// arnResource returns the part of an ELB ARN after marker, e.g.
+// "app/my-lb/50dc6c495c0c9188" for ":loadbalancer/", which CloudWatch uses
+// as the dimension value.
+func arnResource(arn, marker string) (string, bool) {
+	_, resource, ok := strings.Cut(arn, marker)
+	return resource, ok && resource != ""
+}
+
+// fetchTrafficMetrics loads the table series of every source in one batched
+// GetMetricData request.
+func fetchTrafficMetrics(sources map[string]metricSource) (map[string]*trafficMetrics, error) {
+	type role struct {
+		key  string
+		kind string
+	}
+
+	var queries []cloudwatch.MetricQuery
+	roles := map[string]role{}
+	add := func(key, kind string, source metricSource, metric, stat string) {
+		id := fmt.Sprintf("m%d", len(queries))
+		roles[id] = role{key: key, kind: kind}
+		queries = append(queries, cloudwatch.MetricQuery{
+			ID:         id,
+			Namespace:  source.namespace,
+			MetricName: metric,
+			Dimensions: source.dimensions,
+			Stat:       stat,
+		})
+	}
+	for key, source := range sources {
+		add(key, "requests", source, source.requests, "Sum")
+		for _, metric := range source.errors {
+			add(key, "errors", source, metric, "Sum")
+		}
+		add(key, "latency", source, source.latency, "Average")
+	}
+	if len(queries) == 0 {
+		return map[string]*trafficMetrics{}, nil
+	}
+
+	end := time.Now()
+	series, err := cloudwatch.GetMetricData(queries, end.Add(-tableMetricsWindow), end, tableMetricsPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make(map[string]*trafficMetrics, len(sources))
+	for id, values := range series {
+		r := roles[id]
+		m, ok := metrics[r.key]
+		if !ok {
+			m = &trafficMetrics{}
+			metrics[r.key] = m
+		}
+		switch r.kind {
+		case "requests":
+			m.requests = values
+		case "errors":
+			m.errors = sumSeries(m.errors, values)
+		case "latency":
+			m.latency = values
+		}
+	}
+	return metrics, nil
+}
+
+// sumSeries adds b to a point by point, treating missing points as zero
+// unless both are missing.
+func sumSeries(a, b cloudwatch.Series) cloudwatch.Series {
+	if a == nil {
+		return append(cloudwatch.Series(nil), b...)
+	}
+	for idx := range a {
+		if idx >= len(b) || math.IsNaN(b[idx]) {
+			continue
+		}
+		if math.IsNaN(a[idx]) {
+			a[idx] = 0
+		}
+		a[idx] += b[idx]
+	}
+	return a
+}
+
+// metricCells renders the request, 5xx rate and response time columns.
+// A nil m means the metrics are still loading.
+func metricCells(m *trafficMetrics, supported, loading bool) []*tview.TableCell {
+	placeholder := "-"
+	if supported && loading {
+		placeholder = "…"
+	}
+	if m == nil {
+		return []*tview.TableCell{tableCell(placeholder), tableCell(placeholder), tableCell(placeholder)}
+	}
+
+	requests := total(m.requests)
+	errors := total(m.errors)
+	rates := make(cloudwatch.Series, len(m.requests))
+	for idx, value := range m.requests {
+		rates[idx] = math.NaN()
+		if !math.IsNaN(value) && value > 0 {
+			rates[idx] = valueAt(m.errors, idx) / value * 100
+		}
+	}
+
+	rateCell := tableCell(fmt.Sprintf("%s %s", utils.Sparkline(rates), formatRate(errors, requests)))
+	switch {
+	case requests > 0 && errors/requests >= 0.01:
+		rateCell.SetTextColor(tcell.ColorRed)
+	case errors > 0:
+		rateCell.SetTextColor(tcell.ColorYellow)
+	}
+
+	return []*tview.TableCell{
+		tableCell(fmt.Sprintf("%s %s", utils.Sparkline(m.requests), formatCount(requests))),
+		rateCell,
+		tableCell(fmt.Sprintf("%s %s", utils.Sparkline(m.latency), formatLatency(mean(m.latency)))),
+	}
+}
+
+// loadLoadBalancerMetrics fetches the table metrics of every listed load
+// balancer and fills in the metric columns.
+func (s *Service) loadLoadBalancerMetrics() {
+	sources := map[string]metricSource{}
+	for _, row := range s.loadBalancers {
+		if source, ok := lbMetricSource(row); ok {
+			sources[lbMetricKey(row)] = source
+		}
+	}
+	s.lbMetrics = nil
+	s.lbMetricsLoading = len(sources) > 0
+	s.lbMetricsGen++
+	gen := s.lbMetricsGen
+	if len(sources) == 0 {
+		return
+	}
+
+	go func() {
+		metrics, err := fetchTrafficMetrics(sources)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if gen != s.lbMetricsGen {
+				return
+			}
+			s.lbMetricsLoading = false
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("get metric data: %w", err))
+			}
+			s.lbMetrics = metrics
+			s.renderLoadBalancerMetrics()
+		})
+	}()
+}
+
+// renderLoadBalancerMetrics updates the metric columns in place, so the
+// selection survives metrics arriving after the table.
+func (s *Service) renderLoadBalancerMetrics() {
+	for idx, row := range s.filteredLoadBalancers {
+		_, supported := lbMetricSource(row)
+		cells := metricCells(s.lbMetrics[lbMetricKey(row)], supported, s.lbMetricsLoading)
+		for col, cell := range cells {
+			s.lbTable.SetCell(idx+1, lbMetricColumn+col, cell)
+		}
+	}
+}
+
+func (s *Service) loadTargetGroupMetrics() {
+	sources := map[string]metricSource{}
+	for _, entry := range s.targetGroups {
+		if source, ok := targetGroupMetricSource(s.selectedLoadBalancerArn, entry.arn); ok {
+			sources[entry.arn] = source
+		}
+	}
+	s.tgMetrics = nil
+	s.tgMetricsLoading = len(sources) > 0
+	s.tgMetricsGen++
+	gen := s.tgMetricsGen
+	if len(sources) == 0 {
+		return
+	}
+
+	go func() {
+		metrics, err := fetchTrafficMetrics(sources)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if gen != s.tgMetricsGen {
+				return
+			}
+			s.tgMetricsLoading = false
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("get metric data: %w", err))
+			}
+			s.tgMetrics = metrics
+			s.renderTargetGroupMetrics()
+		})
+	}()
+}
+
+func (s *Service) renderTargetGroupMetrics() {
+	for idx, entry := range s.targetGroups {
+		_, supported := targetGroupMetricSource(s.selectedLoadBalancerArn, entry.arn)
+		cells := metricCells(s.tgMetrics[entry.arn], supported, s.tgMetricsLoading)
+		for col, cell := range cells {
+			s.targetGroupTable.SetCell(idx+1, tgMetricColumn+col, cell)
+		}
+	}
+}
+
+func lbMetricKey(row loadBalancerRow) string {
+	if row.classic != nil {
+		return "classic/" + row.name()
+	}
+	return valueOr(row.v2.LoadBalancerArn)
+}
+
+// chartMetric is a metric the detail chart can plot.
+type chartMetric struct {
+	label       string
+	defaultStat int
+	metrics     func(metricSource) []string
+	latency     bool
+}
+
+var chartMetrics = []chartMetric{
+	{label: "Requests", defaultStat: 0, metrics: func(src metricSource) []string { return []string{src.requests} }},
+	{label: "5xx errors", defaultStat: 0, metrics: func(src metricSource) []string { return src.errors }},
+	{label: "Response time", defaultStat: 1, metrics: func(src metricSource) []string { return []string{src.latency} }, latency: true},
+}
+
+var chartStats = []string{"Sum", "Average", "Minimum", "Maximum", "SampleCount"}
+
+// percentileStats only make sense for the response-time metric; request and
+// error counts are not recorded as distributions.
+var percentileStats = []string{"p50", "p90", "p99"}
+
+// stats lists the statistics offered for the metric.
+func (m chartMetric) stats() []string {
+	if !m.latency {
+		return chartStats
+	}
+	return append(append([]string{}, chartStats...), percentileStats...)
+}
+
+// chartPeriod pairs a period with the window it is plotted over.
+type chartPeriod struct {
+	label  string
+	period time.Duration
+	window time.Duration
+}
+
+var chartPeriods = []chartPeriod{
+	{label: "1 minute (last hour)", period: time.Minute, window: time.Hour},
+	{label: "5 minutes (last 6 hours)", period: 5 * time.Minute, window: 6 * time.Hour},
+	{label: "1 hour (last 3 days)", period: time.Hour, window: 3 * 24 * time.Hour},
+	{label: "1 day (last 30 days)", period: 24 * time.Hour, window: 30 * 24 * time.Hour},
+}
+
+// openSelectedMetricsChart charts the selected load balancer or target
+// group.
+func (s *Service) openSelectedMetricsChart() {
+	switch s.current {
+	case lbTab:
+		row, ok := s.selectedLoadBalancer()
+		if !ok {
+			return
+		}
+		source, ok := lbMetricSource(row)
+		if !ok {
+			s.ctx.SetStatus(fmt.Sprintf("No request metrics for %s load balancers", row.lbType()))
+			return
+		}
+		s.openMetricsChart(row.name(), source)
+	case targetGroupTab:
+		row, _ := s.targetGroupTable.GetSelection()
+		if row <= 0 || row-1 >= len(s.targetGroups) {
+			return
+		}
+		entry := s.targetGroups[row-1]
+		source, ok := targetGroupMetricSource(s.selectedLoadBalancerArn, entry.arn)
+		if !ok {
+			s.ctx.SetStatus("Request metrics are only available for application load balancer target groups")
+			return
+		}
+		s.openMetricsChart(elbv2.TargetGroupName(entry.arn), source)
+	}
+}
+
+func (s *Service) openMetricsChart(name string, source metricSource) {
+	metric, period, stat := 0, 1, chartMetrics[0].defaultStat
+
+	chart := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+
+	// gen discards responses for selections that were changed since.
+	gen := 0
+	load := func() {
+		gen++
+		current := gen
+		m, p := chartMetrics[metric], chartPeriods[period]
+		st := m.stats()[stat]
+		chart.SetText("[yellow]Fetching metrics...[-]")
+
+		names := m.metrics(source)
+		var queries []cloudwatch.MetricQuery
+		for idx, metricName := range names {
+			queries = append(queries, cloudwatch.MetricQuery{
+				ID:         fmt.Sprintf("m%d", idx),
+				Namespace:  source.namespace,
+				MetricName: metricName,
+				Dimensions: source.dimensions,
+				Stat:       st,
+			})
+		}
+
+		go func() {
+			end := time.Now()
+			start := end.Add(-p.window)
+			series, err := cloudwatch.GetMetricData(queries, start, end, p.period)
+			s.ctx.App.QueueUpdateDraw(func() {
+				if current != gen {
+					return
+				}
+				if err != nil {
+					chart.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+					return
+				}
+				var values cloudwatch.Series
+				for _, query := range queries {
+					values = sumSeries(values, series[query.ID])
+				}
+				chart.SetText(formatChart(values, m, names, st, start.Truncate(p.period), end))
+			})
+		}()
+	}
+
+	metricLabels := make([]string, 0, len(chartMetrics))
+	for _, m := range chartMetrics {
+		metricLabels = append(metricLabels, m.label)
+	}
+	periodLabels := make([]string, 0, len(chartPeriods))
+	for _, p := range chartPeriods {
+		periodLabels = append(periodLabels, p.label)
+	}
+
+	// SetOptions replaces the selected func, so it is passed on every call.
+	statSelected := func(_ string, idx int) {
+		if idx >= 0 && idx != stat {
+			stat = idx
+			load()
+		}
+	}
+	statDropDown := tview.NewDropDown().
+		SetLabel("Statistic ").
+		SetOptions(chartMetrics[metric].stats(), statSelected).
+		SetCurrentOption(stat)
+	metricDropDown := tview.NewDropDown().
+		SetLabel("Metric ").
+		SetOptions(metricLabels, nil).
+		SetCurrentOption(metric)
+	metricDropDown.SetSelectedFunc(func(_ string, idx int) {
+		if idx == metric {
+			return
+		}
+		metric = idx
+		// Switching metric resets the statistic to the one that suits it.
+		stat = chartMetrics[idx].defaultStat
+		statDropDown.SetOptions(chartMetrics[idx].stats(), statSelected)
+		statDropDown.SetCurrentOption(stat)
+		load()
+	})
+	periodDropDown := tview.NewDropDown().
+		SetLabel("Period ").
+		SetOptions(periodLabels, nil).
+		SetCurrentOption(period)
+	periodDropDown.SetSelectedFunc(func(_ string, idx int) {
+		if idx != period {
+			period = idx
+			load()
+		}
+	})
+
+	controls := tview.NewForm().
+		SetHorizontal(true).
+		AddFormItem(metricDropDown).
+		AddFormItem(periodDropDown).
+		AddFormItem(statDropDown)
+	controls.SetCancelFunc(s.closeModal)
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(controls, 1, 0, true).
+		AddItem(chart, 0, 1, false)
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Metrics for %s", name))
+	view.SetTitleAlign(tview.AlignLeft)
+
+	s.showModal(metricsModalPageName, centerPrimitive(view, 110, chartHeight+10))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(controls)
+	}
+	load()
+}
+
+// formatChart draws values as a bar chart with the peak on the y axis and
+// the window bounds under it.
+func formatChart(values cloudwatch.Series, metric chartMetric, names []string, stat string, start, end time.Time) string {
+	format := func(value float64) string {
+		if metric.latency {
+			return formatLatency(value)
+		}
+		if stat == "Sum" || stat == "SampleCount" {
+			return formatCount(value)
+		}
+		return fmt.Sprintf("%.2f", value)
+	}
+
+	peak, points := 0.0, 0
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		points++
+		peak = max(peak, value)
+	}
+	if points == 0 {
+		return "\n  [gray]No datapoints in this window[-]"
+	}
+
+	labels := []string{format(peak), format(peak / 2), format(0)}
+	width := 0
+	for _, label := range labels {
+		width = max(width, len(label))
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	for idx, row := range utils.BarChart(values, chartHeight) {
+		label := ""
+		switch idx {
+		case 0:
+			label = labels[0]
+		case chartHeight / 2:
+			label = labels[1]
+		case chartHeight - 1:
+			label = labels[2]
+		}
+		fmt.Fprintf(&b, " %*s ┤[green]%s[-]\n", width, label, row)
+	}
+
+	from := start.Local().Format("01-02 15:04")
+	to := end.Local().Format("01-02 15:04")
+	gap := max(len(values)-len(from)-len(to), 1)
+	fmt.Fprintf(&b, " %*s  %s%s%s\n\n", width, "", from, strings.Repeat(" ", gap), to)
+	fmt.Fprintf(&b, " [lightcyan]%s[-] %s of %s   [lightcyan]peak[-] %s   [lightcyan]mean[-] %s   [lightcyan]datapoints[-] %d",
+		metric.label, stat, strings.Join(names, " + "), format(peak), format(mean(values)), points)
+	return b.String()
+}
+
+func total(values cloudwatch.Series) float64 {
+	sum := 0.0
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			sum += value
+		}
+	}
+	return sum
+}
+
+func mean(values cloudwatch.Series) float64 {
+	sum, points := 0.0, 0
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			sum += value
+			points++
+		}
+	}
+	if points == 0 {
+		return math.NaN()
+	}
+	return sum / float64(points)
+}
+
+func valueAt(values cloudwatch.Series, idx int) float64 {
+	if idx >= len(values) || math.IsNaN(values[idx]) {
+		return 0
+	}
+	return values[idx]
+}
+
+func formatCount(value float64) string {
+	switch {
+	case value >= 1e6:
+		return fmt.Sprintf("%.1fM", value/1e6)
+	case value >= 1e3:
+		return fmt.Sprintf("%.1fk", value/1e3)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+func formatRate(errors, requests float64) string {
+	if requests <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", errors/requests*100)
+}
+
+// formatLatency formats a latency reported in seconds.
+func formatLatency(seconds float64) string {
+	switch {
+	case math.IsNaN(seconds):
+		return "-"
+	case seconds >= 1:
+		return fmt.Sprintf("%.2fs", seconds)
+	}
+	return fmt.Sprintf("%.0fms", seconds*1000)
+}
@@ -0,0 +1,472 @@
+package elb
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+var (
+	ruleActionTypes = []string{
+		string(types.ActionTypeEnumForward),
+		string(types.ActionTypeEnumRedirect),
+		string(types.ActionTypeEnumFixedResponse),
+	}
+	redirectStatusCodes = []string{
+		string(types.RedirectActionStatusCodeEnumHttp301),
+		string(types.RedirectActionStatusCodeEnumHttp302),
+	}
+	fixedContentTypes = []string{"text/plain", "text/css", "text/html", "application/javascript", "application/json"}
+)
+
+// ruleFormBaseItems is the number of form items shown regardless of the
+// selected action type (priority, five condition fields and the action).
+const ruleFormBaseItems = 8
+
+func (s *Service) selectedRule() (types.Rule, bool) {
+	row, _ := s.ruleTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.rules) {
+		return types.Rule{}, false
+	}
+	rule := s.rules[row-1]
+	if rule.RuleArn == nil {
+		return types.Rule{}, false
+	}
+	return rule, true
+}
+
+func isDefaultRule(rule types.Rule) bool {
+	return rule.IsDefault != nil && *rule.IsDefault
+}
+
+// sortRules orders rules numerically by priority with the default rule last,
+// matching how the listener evaluates them.
+func sortRules(rules []types.Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if isDefaultRule(rules[i]) != isDefaultRule(rules[j]) {
+			return !isDefaultRule(rules[i])
+		}
+		return rulePriority(rules[i]) < rulePriority(rules[j])
+	})
+}
+
+func rulePriority(rule types.Rule) int {
+	if rule.Priority == nil {
+		return 0
+	}
+	priority, _ := strconv.Atoi(*rule.Priority)
+	return priority
+}
+
+func (s *Service) nextRulePriority() int32 {
+	highest := 0
+	for _, rule := range s.rules {
+		if !isDefaultRule(rule) && rulePriority(rule) > highest {
+			highest = rulePriority(rule)
+		}
+	}
+	return int32(min(highest+1, 50000))
+}
+
+func (s *Service) openCreateRuleForm() {
+	if s.selectedListenerArn == "" {
+		return
+	}
+	spec := elbv2.RuleSpec{
+		Priority: s.nextRulePriority(),
+		Action: elbv2.ActionSpec{
+			Type:               types.ActionTypeEnumForward,
+			RedirectProtocol:   "HTTPS",
+			RedirectStatusCode: types.RedirectActionStatusCodeEnumHttp301,
+			FixedStatusCode:    "200",
+			FixedContentType:   "text/plain",
+		},
+	}
+	s.openRuleForm(nil, spec)
+}
+
+func (s *Service) openEditRuleForm() {
+	rule, ok := s.selectedRule()
+	if !ok {
+		return
+	}
+	if isDefaultRule(rule) {
+		s.ctx.SetStatus("The default rule follows the listener's default action and cannot be edited here")
+		return
+	}
+	spec := elbv2.RuleSpecFromRule(rule)
+	if spec.Action.Type == "" {
+		s.ctx.SetStatus("Selected rule has no forward, redirect or fixed-response action to edit")
+		return
+	}
+	// Seed the other action types so switching the dropdown starts from
+	// sensible values.
+	if spec.Action.RedirectStatusCode == "" {
+		spec.Action.RedirectStatusCode = types.RedirectActionStatusCodeEnumHttp301
+	}
+	if spec.Action.FixedStatusCode == "" {
+		spec.Action.FixedStatusCode = "200"
+		spec.Action.FixedContentType = "text/plain"
+	}
+	s.openRuleForm(&rule, spec)
+}
+
+// openRuleForm shows the create/edit form. existing is nil when creating.
+func (s *Service) openRuleForm(existing *types.Rule, spec elbv2.RuleSpec) {
+	priorityInput := tview.NewInputField().SetLabel("Priority").
+		SetText(strconv.Itoa(int(spec.Priority))).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	hostInput := tview.NewInputField().SetLabel("Host headers").
+		SetText(strings.Join(spec.HostHeaders, ", "))
+	pathInput := tview.NewInputField().SetLabel("Path patterns").
+		SetText(strings.Join(spec.PathPatterns, ", "))
+	headerNameInput := tview.NewInputField().SetLabel("HTTP header").
+		SetText(spec.HTTPHeaderName)
+	headerValuesInput := tview.NewInputField().SetLabel("Header values").
+		SetText(strings.Join(spec.HTTPHeaderValues, ", "))
+	queryInput := tview.NewInputField().SetLabel("Query strings").
+		SetText(elbv2.FormatQueryStrings(spec.QueryStrings)).
+		SetPlaceholder("key=value, value")
+	sourceInput := tview.NewInputField().SetLabel("Source IPs").
+		SetText(strings.Join(spec.SourceIPs, ", ")).
+		SetPlaceholder("10.0.0.0/8, 192.0.2.1/32")
+
+	targetGroupsText := elbv2.FormatTargetGroups(spec.Action.TargetGroups)
+	targetGroupsInput := tview.NewInputField().SetLabel("Target groups").
+		SetText(targetGroupsText).
+		SetPlaceholder("name[=weight], ...")
+
+	redirectProtocolInput := tview.NewInputField().SetLabel("Protocol").SetText(spec.Action.RedirectProtocol)
+	redirectHostInput := tview.NewInputField().SetLabel("Host").SetText(spec.Action.RedirectHost)
+	redirectPortInput := tview.NewInputField().SetLabel("Port").SetText(spec.Action.RedirectPort)
+	redirectPathInput := tview.NewInputField().SetLabel("Path").SetText(spec.Action.RedirectPath)
+	redirectQueryInput := tview.NewInputField().SetLabel("Query").SetText(spec.Action.RedirectQuery)
+	redirectStatus := tview.NewDropDown().SetLabel("Status code").
+		SetOptions(redirectStatusCodes, nil).
+		SetCurrentOption(indexOf(redirectStatusCodes, string(spec.Action.RedirectStatusCode)))
+
+	fixedStatusInput := tview.NewInputField().SetLabel("Status code").
+		SetText(spec.Action.FixedStatusCode).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	fixedContentType := tview.NewDropDown().SetLabel("Content type").
+		SetOptions(fixedContentTypes, nil).
+		SetCurrentOption(indexOf(fixedContentTypes, spec.Action.FixedContentType))
+	fixedBodyInput := tview.NewInputField().SetLabel("Body").SetText(spec.Action.FixedMessageBody)
+
+	form := tview.NewForm().
+		AddFormItem(priorityInput).
+		AddFormItem(hostInput).
+		AddFormItem(pathInput).
+		AddFormItem(headerNameInput).
+		AddFormItem(headerValuesInput).
+		AddFormItem(queryInput).
+		AddFormItem(sourceInput)
+
+	actionType := tview.NewDropDown().SetLabel("Action")
+	form.AddFormItem(actionType)
+	actionType.SetOptions(ruleActionTypes, func(option string, _ int) {
+		for form.GetFormItemCount() > ruleFormBaseItems {
+			form.RemoveFormItem(ruleFormBaseItems)
+		}
+		switch types.ActionTypeEnum(option) {
+		case types.ActionTypeEnumForward:
+			form.AddFormItem(targetGroupsInput)
+		case types.ActionTypeEnumRedirect:
+			form.AddFormItem(redirectProtocolInput).
+				AddFormItem(redirectHostInput).
+				AddFormItem(redirectPortInput).
+				AddFormItem(redirectPathInput).
+				AddFormItem(redirectQueryInput).
+				AddFormItem(redirectStatus)
+		case types.ActionTypeEnumFixedResponse:
+			form.AddFormItem(fixedStatusInput).
+				AddFormItem(fixedContentType).
+				AddFormItem(fixedBodyInput)
+		}
+	})
+	actionType.SetCurrentOption(indexOf(ruleActionTypes, string(spec.Action.Type)))
+
+	title := "New rule"
+	if existing != nil {
+		title = fmt.Sprintf("Edit rule %s", valueOr(existing.Priority))
+	}
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf("%s – comma separate multiple values", title))
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(s.closeModal)
+
+	formPage := centerPrimitive(form, 100, 30)
+	back := func() {
+		s.showModal(ruleModalPageName, formPage)
+		if s.ctx.App != nil {
+			s.ctx.App.SetFocus(form)
+		}
+	}
+
+	form.AddButton("Review", func() {
+		updated := spec
+		priority, err := strconv.Atoi(strings.TrimSpace(priorityInput.GetText()))
+		if err != nil {
+			s.ctx.SetError(fmt.Errorf("priority must be a number"))
+			return
+		}
+		updated.Priority = int32(priority)
+		updated.HostHeaders = elbv2.ParseList(hostInput.GetText())
+		updated.PathPatterns = elbv2.ParseList(pathInput.GetText())
+		updated.HTTPHeaderName = strings.TrimSpace(headerNameInput.GetText())
+		updated.HTTPHeaderValues = elbv2.ParseList(headerValuesInput.GetText())
+		updated.QueryStrings = elbv2.ParseQueryStrings(queryInput.GetText())
+		updated.SourceIPs = elbv2.ParseList(sourceInput.GetText())
+
+		_, option := actionType.GetCurrentOption()
+		updated.Action = elbv2.ActionSpec{Type: types.ActionTypeEnum(option)}
+		switch updated.Action.Type {
+		case types.ActionTypeEnumForward:
+			updated.Action.Stickiness = spec.Action.Stickiness
+		case types.ActionTypeEnumRedirect:
+			_, status := redirectStatus.GetCurrentOption()
+			updated.Action.RedirectProtocol = strings.ToUpper(strings.TrimSpace(redirectProtocolInput.GetText()))
+			updated.Action.RedirectHost = strings.TrimSpace(redirectHostInput.GetText())
+			updated.Action.RedirectPort = strings.TrimSpace(redirectPortInput.GetText())
+			updated.Action.RedirectPath = strings.TrimSpace(redirectPathInput.GetText())
+			updated.Action.RedirectQuery = strings.TrimSpace(redirectQueryInput.GetText())
+			updated.Action.RedirectStatusCode = types.RedirectActionStatusCodeEnum(status)
+		case types.ActionTypeEnumFixedResponse:
+			_, contentType := fixedContentType.GetCurrentOption()
+			updated.Action.FixedStatusCode = strings.TrimSpace(fixedStatusInput.GetText())
+			updated.Action.FixedContentType = contentType
+			updated.Action.FixedMessageBody = fixedBodyInput.GetText()
+		}
+
+		targetGroups := strings.TrimSpace(targetGroupsInput.GetText())
+		if updated.Action.Type != types.ActionTypeEnumForward || targetGroups == targetGroupsText {
+			// Unchanged target groups keep their ARNs; no lookup needed.
+			if updated.Action.Type == types.ActionTypeEnumForward {
+				updated.Action.TargetGroups = spec.Action.TargetGroups
+			}
+			s.reviewRuleChange(existing, spec, updated, back)
+			return
+		}
+
+		s.ctx.SetStatus("Resolving target groups...")
+		go func() {
+			groups, err := elbv2.ListTargetGroups()
+			var tuples []types.TargetGroupTuple
+			if err == nil {
+				tuples, err = elbv2.ParseTargetGroups(targetGroups, groups)
+			}
+			s.ctx.App.QueueUpdateDraw(func() {
+				if err != nil {
+					s.ctx.SetError(fmt.Errorf("target groups: %w", err))
+					return
+				}
+				updated.Action.TargetGroups = tuples
+				s.reviewRuleChange(existing, spec, updated, back)
+			})
+		}()
+	})
+	form.AddButton("Cancel", s.closeModal)
+
+	s.ctx.SetError(nil)
+	back()
+}
+
+// reviewRuleChange validates the edited rule and asks for confirmation with a
+// diff against the current rule before creating or modifying it.
+func (s *Service) reviewRuleChange(existing *types.Rule, before, after elbv2.RuleSpec, back func()) {
+	if err := after.Validate(); err != nil {
+		s.ctx.SetError(err)
+		return
+	}
+	s.ctx.SetError(nil)
+
+	listenerArn := s.selectedListenerArn
+	if existing == nil {
+		s.confirmChange("Create rule", utils.DiffLines(nil, after.Describe()), back, "", func() (string, error) {
+			rule, err := elbv2.CreateRule(&listenerArn, after)
+			if err != nil || rule == nil {
+				return "", err
+			}
+			return valueOr(rule.RuleArn), nil
+		}, fmt.Sprintf("Created rule %d", after.Priority))
+		return
+	}
+
+	beforeLines, afterLines := before.Describe(), after.Describe()
+	diff := utils.DiffLines(beforeLines, afterLines)
+	priorityChanged := before.Priority != after.Priority
+	// Describe starts with the priority line; everything after it is
+	// conditions and actions.
+	bodyChanged := strings.Join(beforeLines[1:], "\n") != strings.Join(afterLines[1:], "\n")
+	if !priorityChanged && !bodyChanged {
+		s.ctx.SetStatus("No changes to apply")
+		return
+	}
+
+	ruleArn := valueOr(existing.RuleArn)
+	s.confirmChange(fmt.Sprintf("Modify rule %s", valueOr(existing.Priority)), diff, back, ruleArn, func() (string, error) {
+		if bodyChanged {
+			if _, err := elbv2.ModifyRule(&ruleArn, after); err != nil {
+				return "", err
+			}
+		}
+		if priorityChanged {
+			return "", elbv2.SetRulePriorities(map[string]int32{ruleArn: after.Priority})
+		}
+		return "", nil
+	}, fmt.Sprintf("Updated rule %d", after.Priority))
+}
+
+func (s *Service) confirmDeleteRule() {
+	rule, ok := s.selectedRule()
+	if !ok {
+		return
+	}
+	if isDefaultRule(rule) {
+		s.ctx.SetStatus("The default rule cannot be deleted")
+		return
+	}
+	ruleArn := valueOr(rule.RuleArn)
+	diff := utils.DiffLines(elbv2.RuleSpecFromRule(rule).Describe(), nil)
+	s.confirmChange(fmt.Sprintf("Delete rule %s", valueOr(rule.Priority)), diff, nil, "", func() (string, error) {
+		return "", elbv2.DeleteRule(&ruleArn)
+	}, fmt.Sprintf("Deleted rule %s", valueOr(rule.Priority)))
+}
+
+// moveSelectedRule swaps the priority of the selected rule with its
+// neighbour; delta is -1 to evaluate it earlier and +1 to evaluate it later.
+func (s *Service) moveSelectedRule(delta int) {
+	rule, ok := s.selectedRule()
+	if !ok {
+		return
+	}
+	if isDefaultRule(rule) {
+		s.ctx.SetStatus("The default rule is always evaluated last")
+		return
+	}
+
+	ordered := make([]types.Rule, 0, len(s.rules))
+	for _, candidate := range s.rules {
+		if !isDefaultRule(candidate) {
+			ordered = append(ordered, candidate)
+		}
+	}
+	sortRules(ordered)
+
+	idx := -1
+	for i, candidate := range ordered {
+		if valueOr(candidate.RuleArn) == valueOr(rule.RuleArn) {
+			idx = i
+		}
+	}
+	neighbour := idx + delta
+	if idx < 0 || neighbour < 0 || neighbour >= len(ordered) {
+		s.ctx.SetStatus("Rule is already at the edge of the priority order")
+		return
+	}
+	other := ordered[neighbour]
+
+	from, to := rulePriority(rule), rulePriority(other)
+	priorities := map[string]int32{
+		valueOr(rule.RuleArn):  int32(to),
+		valueOr(other.RuleArn): int32(from),
+	}
+	diff := []string{
+		fmt.Sprintf("- %d: %s", from, describeRuleBriefly(rule)),
+		fmt.Sprintf("- %d: %s", to, describeRuleBriefly(other)),
+		fmt.Sprintf("+ %d: %s", to, describeRuleBriefly(rule)),
+		fmt.Sprintf("+ %d: %s", from, describeRuleBriefly(other)),
+	}
+
+	ruleArn := valueOr(rule.RuleArn)
+	s.confirmChange("Reorder rules (SetRulePriorities)", diff, nil, ruleArn, func() (string, error) {
+		return "", elbv2.SetRulePriorities(priorities)
+	}, fmt.Sprintf("Moved rule %d to priority %d", from, to))
+}
+
+func describeRuleBriefly(rule types.Rule) string {
+	condType, condValue := summarizeCondition(rule.Conditions)
+	actionType, target := summarizeRuleAction(rule.Actions)
+	return fmt.Sprintf("%s %s → %s %s", condType, condValue, actionType, target)
+}
+
+// confirmChange shows the pending change as a diff and only calls apply once
+// the user confirms it. back, when set, returns to the form the change came
+// from; otherwise cancelling closes the modal. selectArn is the rule to keep
+// selected once the rules reload; apply runs off the UI goroutine and
+// returns the ARN of a rule it created, if any.
+func (s *Service) confirmChange(title string, diff []string, back func(), selectArn string, apply func() (string, error), done string) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(formatDiff(diff))
+
+	cancel := back
+	if cancel == nil {
+		cancel = s.closeModal
+	}
+
+	buttons := tview.NewForm().SetButtonsAlign(tview.AlignCenter)
+	buttons.AddButton("Apply", func() {
+		s.ctx.SetStatus(fmt.Sprintf("%s...", title))
+		s.ctx.SetError(nil)
+		listenerArn := s.selectedListenerArn
+		if selectArn != "" {
+			s.selectRuleArn = selectArn
+		}
+		go func() {
+			created, err := apply()
+			s.ctx.App.QueueUpdateDraw(func() {
+				if err != nil {
+					s.ctx.SetError(fmt.Errorf("%s: %w", strings.ToLower(title), err))
+					return
+				}
+				s.closeModal()
+				s.ctx.SetStatus(done)
+				if created != "" {
+					s.selectRuleArn = created
+				}
+				s.loadRules(listenerArn)
+			})
+		}()
+	})
+	buttons.AddButton("Back", cancel)
+	buttons.SetCancelFunc(cancel)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	layout.SetBorder(true)
+	layout.SetTitle(fmt.Sprintf("%s – review changes", title))
+	layout.SetTitleAlign(tview.AlignLeft)
+
+	s.showModal(confirmModalPageName, centerPrimitive(layout, 100, 20))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(buttons)
+	}
+}
+
+func formatDiff(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&b, "[%s]%s[-]\n", utils.DiffColor(line), tview.Escape(line))
+	}
+	return b.String()
+}
+
+func indexOf(options []string, value string) int {
+	for idx, option := range options {
+		if option == value {
+			return idx
+		}
+	}
+	return 0
+}
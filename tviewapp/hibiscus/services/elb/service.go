@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	classicelb "github.com/jaehong21/hibiscus/internal/aws/elb"
 	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
 	"github.com/jaehong21/hibiscus/tviewapp/hibiscus"
 )
@@ -18,36 +20,99 @@ const (
 	lbTab tab = iota
 	listenerTab
 	ruleTab
+	targetGroupTab
+	targetTab
+	classicTab
+)
+
+const (
+	contentPageName         = "elb-content"
+	ruleModalPageName       = "elb-rule-modal"
+	ruleDetailModalPageName = "elb-rule-detail-modal"
+	lbDetailModalPageName   = "elb-lb-detail-modal"
+	confirmModalPageName    = "elb-confirm-modal"
+	trafficModalPageName    = "elb-traffic-modal"
+	dnsModalPageName        = "elb-dns-modal"
+	metricsModalPageName    = "elb-metrics-modal"
+)
+
+// lbMetricColumn and tgMetricColumn are where the metric columns start in
+// the load balancer and target group tables.
+const (
+	lbMetricColumn = 5
+	tgMetricColumn = 6
 )
 
 // Service implements the hibiscus.Service interface for the ELB view.
 type Service struct {
 	ctx hibiscus.ServiceContext
 
+	root   *tview.Pages
 	layout *tview.Flex
 	pages  *tview.Pages
 	filter *tview.InputField
 
-	lbTable       *tview.Table
-	listenerTable *tview.Table
-	ruleTable     *tview.Table
+	lbTable          *tview.Table
+	listenerTable    *tview.Table
+	ruleTable        *tview.Table
+	targetGroupTable *tview.Table
+	targetTable      *tview.Table
+
+	classicInfo          *tview.TextView
+	classicListenerTable *tview.Table
+	classicInstanceTable *tview.Table
 
 	current tab
 
-	loadBalancers         []types.LoadBalancer
-	filteredLoadBalancers []types.LoadBalancer
+	loadBalancers         []loadBalancerRow
+	filteredLoadBalancers []loadBalancerRow
 	listeners             []types.Listener
 	rules                 []types.Rule
+	targetGroups          []targetGroupEntry
+	targets               []types.TargetHealthDescription
+	instanceStates        []elbtypes.InstanceState
 
 	selectedLoadBalancerArn  string
 	selectedLoadBalancerName string
 	selectedListenerArn      string
-
-	active bool
+	selectedTargetGroupArn   string
+	selectedTargetGroupName  string
+	selectedClassic          *elbtypes.LoadBalancerDescription
+
+	// targetGroupOwner is the rule or listener the target group view was
+	// opened from.
+	targetGroupOwner actionOwner
+
+	// selectRuleArn is the rule to select after the rule table next reloads.
+	selectRuleArn string
+
+	// pendingLoadBalancer is the ARN, name or DNS name another service asked
+	// to show; it is selected once load balancers are loaded.
+	pendingLoadBalancer  string
+	loadingLoadBalancers bool
+
+	// shift is the traffic-shift state of the last opened forward action and
+	// shiftHistory the weights to roll back to, keyed by rule/listener ARN.
+	shift        *trafficShift
+	shiftHistory map[string]elbv2.Weights
+	shiftRender  func()
+
+	// lbMetrics and tgMetrics hold the last hour of traffic per load
+	// balancer (keyed by lbMetricKey) and target group ARN. The generation
+	// counters discard responses for tables that were reloaded since.
+	lbMetrics        map[string]*trafficMetrics
+	lbMetricsLoading bool
+	lbMetricsGen     int
+	tgMetrics        map[string]*trafficMetrics
+	tgMetricsLoading bool
+	tgMetricsGen     int
+
+	activeModal string
+	active      bool
 }
 
 func New(ctx hibiscus.ServiceContext) hibiscus.Service {
-	svc := &Service{ctx: ctx, current: lbTab}
+	svc := &Service{ctx: ctx, current: lbTab, shiftHistory: map[string]elbv2.Weights{}}
 
 	svc.filter = tview.NewInputField().
 		SetLabel("Filter (/): ").
@@ -56,16 +121,32 @@ func New(ctx hibiscus.ServiceContext) hibiscus.Service {
 	svc.lbTable = buildTable("Load balancers")
 	svc.listenerTable = buildTable("Listeners")
 	svc.ruleTable = buildTable("Rules")
+	svc.targetGroupTable = buildTable("Target groups")
+	svc.targetTable = buildTable("Targets")
+	svc.classicListenerTable = buildTable("Listeners")
+	svc.classicInstanceTable = buildTable("Instance health")
+	svc.classicInfo = tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+
+	classicView := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(svc.classicInfo, 2, 0, false).
+		AddItem(svc.classicListenerTable, 0, 1, false).
+		AddItem(svc.classicInstanceTable, 0, 2, true)
 
 	svc.pages = tview.NewPages()
 	svc.pages.AddPage("lbs", svc.lbTable, true, true)
 	svc.pages.AddPage("listeners", svc.listenerTable, true, false)
 	svc.pages.AddPage("rules", svc.ruleTable, true, false)
+	svc.pages.AddPage("targetgroups", svc.targetGroupTable, true, false)
+	svc.pages.AddPage("targets", svc.targetTable, true, false)
+	svc.pages.AddPage("classic", classicView, true, false)
 
 	svc.layout = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(svc.filter, 1, 0, true).
 		AddItem(svc.pages, 0, 1, true)
 
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
 	svc.filter.SetDoneFunc(func(key tcell.Key) {
 		switch key {
 		case tcell.KeyEnter:
@@ -80,10 +161,10 @@ func New(ctx hibiscus.ServiceContext) hibiscus.Service {
 
 func (s *Service) Name() string { return "elb" }
 func (s *Service) Title() string {
-	return "Elastic Load Balancing – load balancers › listeners › rules"
+	return "Elastic Load Balancing – load balancers › listeners › rules › target groups › targets (classic: listeners & instance health)"
 }
 func (s *Service) Primitive() tview.Primitive {
-	return s.layout
+	return s.root
 }
 
 func (s *Service) Init() {
@@ -111,12 +192,27 @@ func (s *Service) Refresh() {
 			s.loadRules(s.selectedListenerArn)
 			return
 		}
+	case targetGroupTab:
+		if len(s.targetGroupOwner.actions) > 0 {
+			s.openTargetGroups(s.targetGroupOwner)
+			return
+		}
+	case targetTab:
+		if s.selectedTargetGroupArn != "" {
+			s.loadTargetHealth(s.selectedTargetGroupArn)
+			return
+		}
+	case classicTab:
+		if s.selectedClassic != nil {
+			s.loadInstanceHealth()
+			return
+		}
 	}
 	s.loadLoadBalancers()
 }
 
 func (s *Service) EnterFilterMode() bool {
-	if !s.canFocus() {
+	if !s.canFocus() || s.modalVisible() {
 		return false
 	}
 	if s.current != lbTab {
@@ -127,8 +223,10 @@ func (s *Service) EnterFilterMode() bool {
 	return true
 }
 
+// InFilterMode also reports true while a modal is open so typing into its
+// text fields does not trigger global shortcuts.
 func (s *Service) InFilterMode() bool {
-	return s.filter.HasFocus()
+	return s.filter.HasFocus() || s.modalVisible()
 }
 
 func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
@@ -136,6 +234,14 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
 	switch event.Key() {
 	case tcell.KeyEsc:
 		if s.filter.HasFocus() {
@@ -143,6 +249,19 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 			return nil
 		}
 		switch s.current {
+		case classicTab:
+			s.showLoadBalancerTab()
+			return nil
+		case targetTab:
+			s.showTargetGroupTab()
+			return nil
+		case targetGroupTab:
+			if s.targetGroupOwner.from == listenerTab {
+				s.showListenerTab()
+			} else {
+				s.showRuleTab()
+			}
+			return nil
 		case ruleTab:
 			s.showListenerTab()
 			return nil
@@ -162,6 +281,74 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 		case listenerTab:
 			s.openSelectedListener()
 			return nil
+		case ruleTab:
+			s.openSelectedRuleTargets()
+			return nil
+		case targetGroupTab:
+			s.openSelectedTargetGroup()
+			return nil
+		}
+	case tcell.KeyTab:
+		if s.current == classicTab {
+			s.toggleClassicFocus()
+			return nil
+		}
+	case tcell.KeyCtrlD:
+		if s.current == ruleTab {
+			s.confirmDeleteRule()
+			return nil
+		}
+	}
+
+	switch event.Rune() {
+	case 't', 'T':
+		if s.current == listenerTab {
+			s.openSelectedListenerTargets()
+			return nil
+		}
+	case 'd', 'D':
+		switch s.current {
+		case lbTab:
+			s.openLoadBalancerDetail()
+			return nil
+		case ruleTab:
+			s.openRuleDetail()
+			return nil
+		}
+	case 'm', 'M':
+		if s.current == lbTab || s.current == targetGroupTab {
+			s.openSelectedMetricsChart()
+			return nil
+		}
+	case 'p', 'P':
+		if s.current == lbTab {
+			s.openDNSReferences()
+			return nil
+		}
+	case 'w', 'W':
+		if s.current == ruleTab || s.current == listenerTab || s.current == targetGroupTab {
+			s.openSelectedTrafficShift()
+			return nil
+		}
+	case 'n', 'N':
+		if s.current == ruleTab {
+			s.openCreateRuleForm()
+			return nil
+		}
+	case 'e', 'E':
+		if s.current == ruleTab {
+			s.openEditRuleForm()
+			return nil
+		}
+	case '[':
+		if s.current == ruleTab {
+			s.moveSelectedRule(-1)
+			return nil
+		}
+	case ']':
+		if s.current == ruleTab {
+			s.moveSelectedRule(1)
+			return nil
 		}
 	}
 
@@ -175,6 +362,12 @@ func (s *Service) exitFilterMode() {
 		s.setFocus(s.listenerTable)
 	case ruleTab:
 		s.setFocus(s.ruleTable)
+	case targetGroupTab:
+		s.setFocus(s.targetGroupTable)
+	case targetTab:
+		s.setFocus(s.targetTable)
+	case classicTab:
+		s.setFocus(s.classicInstanceTable)
 	default:
 		s.setFocus(s.lbTable)
 	}
@@ -197,10 +390,7 @@ func (s *Service) applyFilter(query string) {
 	}
 
 	for _, lb := range s.loadBalancers {
-		if lb.LoadBalancerName == nil {
-			continue
-		}
-		if strings.Contains(strings.ToLower(*lb.LoadBalancerName), query) {
+		if strings.Contains(strings.ToLower(lb.name()), query) {
 			s.filteredLoadBalancers = append(s.filteredLoadBalancers, lb)
 		}
 	}
@@ -216,11 +406,15 @@ func (s *Service) openSelectedLoadBalancer() {
 		return
 	}
 	lb := s.filteredLoadBalancers[row-1]
-	if lb.LoadBalancerArn == nil {
+	if lb.classic != nil {
+		s.openClassicLoadBalancer(lb.classic)
+		return
+	}
+	if lb.v2.LoadBalancerArn == nil {
 		return
 	}
-	s.selectedLoadBalancerArn = *lb.LoadBalancerArn
-	s.selectedLoadBalancerName = valueOr(lb.LoadBalancerName)
+	s.selectedLoadBalancerArn = *lb.v2.LoadBalancerArn
+	s.selectedLoadBalancerName = lb.name()
 	s.loadListeners(s.selectedLoadBalancerArn)
 }
 
@@ -240,19 +434,37 @@ func (s *Service) openSelectedListener() {
 func (s *Service) loadLoadBalancers() {
 	s.ctx.SetStatus("Fetching load balancers...")
 	s.ctx.SetError(nil)
+	s.loadingLoadBalancers = true
 
 	go func() {
 		lbs, err := elbv2.DescribeLoadBalancers()
-		s.ctx.App.QueueUpdateDraw(func() {
-			if err != nil {
+		if err != nil {
+			s.ctx.App.QueueUpdateDraw(func() {
+				s.loadingLoadBalancers = false
+				s.pendingLoadBalancer = ""
 				s.ctx.SetError(fmt.Errorf("describe load balancers: %w", err))
-				return
-			}
-			s.loadBalancers = lbs
-			s.filteredLoadBalancers = append([]types.LoadBalancer(nil), lbs...)
+			})
+			return
+		}
+		// Classic load balancers are listed best effort so a missing
+		// elasticloadbalancing permission does not hide the v2 ones.
+		classic, classicErr := classicelb.DescribeLoadBalancers()
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.loadingLoadBalancers = false
+			s.loadBalancers = loadBalancerRows(lbs, classic)
+			s.filteredLoadBalancers = append([]loadBalancerRow(nil), s.loadBalancers...)
+			s.loadLoadBalancerMetrics()
 			s.renderLoadBalancers()
 			s.showLoadBalancerTab()
-			s.ctx.SetStatus(fmt.Sprintf("Loaded %d load balancers", len(lbs)))
+			pending := s.pendingLoadBalancer
+			if pending != "" && !s.selectPendingLoadBalancer() {
+				s.pendingLoadBalancer = ""
+				s.ctx.SetError(fmt.Errorf("load balancer %s not found", pending))
+			}
+			if classicErr != nil {
+				s.ctx.SetError(fmt.Errorf("describe classic load balancers: %w", classicErr))
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d load balancers (%d classic)", len(s.loadBalancers), len(classic)))
 		})
 	}()
 }
@@ -295,6 +507,7 @@ func (s *Service) loadRules(listenerArn string) {
 				s.ctx.SetError(fmt.Errorf("describe rules: %w", err))
 				return
 			}
+			sortRules(rules)
 			s.rules = rules
 			s.renderRules()
 			s.showRuleTab()
@@ -307,7 +520,7 @@ func (s *Service) renderLoadBalancers() {
 	table := s.lbTable
 	table.Clear()
 
-	headers := []string{"Name", "Type", "DNS name", "State", "Created"}
+	headers := append([]string{"Name", "Type", "DNS name", "State", "Created"}, metricHeaders...)
 	for col, title := range headers {
 		table.SetCell(0, col, headerCell(title))
 	}
@@ -318,24 +531,17 @@ func (s *Service) renderLoadBalancers() {
 	}
 
 	for idx, lb := range s.filteredLoadBalancers {
-		table.SetCell(idx+1, 0, tableCell(valueOr(lb.LoadBalancerName)))
-		lbType := "Unknown"
-		if lb.Type != "" {
-			lbType = string(lb.Type)
-		}
-		table.SetCell(idx+1, 1, tableCell(lbType))
-		table.SetCell(idx+1, 2, tableCell(valueOr(lb.DNSName)))
-		state := "Unknown"
-		if lb.State != nil && lb.State.Code != "" {
-			state = string(lb.State.Code)
-		}
-		table.SetCell(idx+1, 3, tableCell(state))
+		table.SetCell(idx+1, 0, tableCell(lb.name()))
+		table.SetCell(idx+1, 1, tableCell(lb.lbType()))
+		table.SetCell(idx+1, 2, tableCell(lb.dnsName()))
+		table.SetCell(idx+1, 3, tableCell(lb.state()))
 		created := ""
-		if lb.CreatedTime != nil {
-			created = lb.CreatedTime.Local().Format("2006-01-02 15:04:05")
+		if createdTime := lb.createdTime(); createdTime != nil {
+			created = createdTime.Local().Format("2006-01-02 15:04:05")
 		}
 		table.SetCell(idx+1, 4, tableCell(created))
 	}
+	s.renderLoadBalancerMetrics()
 
 	table.Select(1, 0)
 }
@@ -400,6 +606,14 @@ func (s *Service) renderRules() {
 	}
 
 	table.Select(1, 0)
+	if s.selectRuleArn != "" {
+		for idx, rule := range s.rules {
+			if valueOr(rule.RuleArn) == s.selectRuleArn {
+				table.Select(idx+1, 0)
+			}
+		}
+		s.selectRuleArn = ""
+	}
 }
 
 func (s *Service) showLoadBalancerTab() {
@@ -431,94 +645,57 @@ func (s *Service) showRuleTab() {
 	s.setFocus(s.ruleTable)
 }
 
+// summarizeAction renders every action of a listener in evaluation order.
 func summarizeAction(actions []types.Action) string {
 	if len(actions) == 0 {
 		return "-"
 	}
-	action := actions[0]
-	info := string(action.Type)
-	switch action.Type {
-	case types.ActionTypeEnumForward:
-		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 0 {
-			names := []string{}
-			for _, tg := range action.ForwardConfig.TargetGroups {
-				if tg.TargetGroupArn != nil {
-					parts := strings.Split(*tg.TargetGroupArn, "/")
-					names = append(names, parts[len(parts)-1])
-				}
-			}
-			if len(names) > 0 {
-				info = fmt.Sprintf("Forward → %s", strings.Join(names, ", "))
-			} else {
-				info = "Forward"
-			}
-		} else {
-			info = "Forward"
-		}
-	case types.ActionTypeEnumRedirect:
-		if action.RedirectConfig != nil {
-			proto := valueOr(action.RedirectConfig.Protocol)
-			port := valueOr(action.RedirectConfig.Port)
-			info = fmt.Sprintf("Redirect → %s:%s", proto, port)
-		}
-	case types.ActionTypeEnumFixedResponse:
-		if action.FixedResponseConfig != nil && action.FixedResponseConfig.StatusCode != nil {
-			info = fmt.Sprintf("Fixed %s", *action.FixedResponseConfig.StatusCode)
-		}
+	parts := make([]string, 0, len(actions))
+	for _, action := range orderedActions(actions) {
+		parts = append(parts, describeAction(action))
 	}
-	return info
+	return strings.Join(parts, "; ")
 }
 
+// summarizeCondition lists every condition field and its values; values of
+// different conditions are separated by " | " in the same order as the fields.
 func summarizeCondition(conditions []types.RuleCondition) (string, string) {
 	if len(conditions) == 0 {
 		return "-", "-"
 	}
-	cond := conditions[0]
-	kind := valueOr(cond.Field)
-	switch {
-	case cond.PathPatternConfig != nil && len(cond.PathPatternConfig.Values) > 0:
-		return kind, strings.Join(cond.PathPatternConfig.Values, ", ")
-	case cond.HostHeaderConfig != nil && len(cond.HostHeaderConfig.Values) > 0:
-		return kind, strings.Join(cond.HostHeaderConfig.Values, ", ")
-	case len(cond.Values) > 0:
-		return kind, strings.Join(cond.Values, ", ")
-	default:
-		return kind, "-"
+	kinds := make([]string, 0, len(conditions))
+	values := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		kind, value := describeCondition(cond)
+		kinds = append(kinds, kind)
+		values = append(values, value)
 	}
+	return strings.Join(kinds, ", "), strings.Join(values, " | ")
 }
 
+// summarizeRuleAction returns the chain of action types (authenticate actions
+// first) and the target of the final, routing action.
 func summarizeRuleAction(actions []types.Action) (string, string) {
 	if len(actions) == 0 {
 		return "-", "-"
 	}
-	action := actions[0]
+	ordered := orderedActions(actions)
+	kinds := make([]string, 0, len(ordered))
+	for _, action := range ordered {
+		kinds = append(kinds, string(action.Type))
+	}
+
+	action := ordered[len(ordered)-1]
 	target := "-"
 	switch action.Type {
 	case types.ActionTypeEnumForward:
-		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 0 {
-			names := []string{}
-			for _, tg := range action.ForwardConfig.TargetGroups {
-				if tg.TargetGroupArn != nil {
-					parts := strings.Split(*tg.TargetGroupArn, "/")
-					names = append(names, parts[len(parts)-1])
-				}
-			}
-			if len(names) > 0 {
-				target = strings.Join(names, ", ")
-			}
-		}
-	case types.ActionTypeEnumRedirect:
-		if action.RedirectConfig != nil {
-			proto := valueOr(action.RedirectConfig.Protocol)
-			port := valueOr(action.RedirectConfig.Port)
-			target = fmt.Sprintf("%s:%s", proto, port)
-		}
-	case types.ActionTypeEnumFixedResponse:
-		if action.FixedResponseConfig != nil && action.FixedResponseConfig.StatusCode != nil {
-			target = *action.FixedResponseConfig.StatusCode
+		if tuples := forwardedTargetGroups(actions); len(tuples) > 0 {
+			target = elbv2.FormatTargetGroups(tuples)
 		}
+	case types.ActionTypeEnumRedirect, types.ActionTypeEnumFixedResponse:
+		_, target, _ = strings.Cut(describeAction(action), " ")
 	}
-	return string(action.Type), target
+	return strings.Join(kinds, " → "), target
 }
 
 func headerCell(title string) *tview.TableCell {
@@ -555,20 +732,64 @@ func (s *Service) canFocus() bool {
 	return s.ctx.App != nil && s.active
 }
 
+// setFocus leaves focus alone while a modal is open so a background reload
+// does not pull it back to the tables.
 func (s *Service) setFocus(p tview.Primitive) {
-	if !s.canFocus() || p == nil {
+	if !s.canFocus() || p == nil || s.modalVisible() {
 		return
 	}
 	s.ctx.App.SetFocus(p)
 }
 
 func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
 	switch s.current {
 	case listenerTab:
 		s.setFocus(s.listenerTable)
 	case ruleTab:
 		s.setFocus(s.ruleTable)
+	case targetGroupTab:
+		s.setFocus(s.targetGroupTable)
+	case targetTab:
+		s.setFocus(s.targetTable)
+	case classicTab:
+		s.setFocus(s.classicInstanceTable)
 	default:
 		s.setFocus(s.lbTable)
 	}
 }
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
@@ -0,0 +1,331 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+)
+
+// targetGroupEntry pairs a forwarded target group with the weight assigned
+// by the action that references it.
+type targetGroupEntry struct {
+	arn    string
+	weight *int32
+	group  *types.TargetGroup
+}
+
+// forwardedTargetGroups collects every target group referenced by forward
+// actions, whether through ForwardConfig or the legacy TargetGroupArn field.
+func forwardedTargetGroups(actions []types.Action) []types.TargetGroupTuple {
+	var (
+		tuples []types.TargetGroupTuple
+		seen   = map[string]bool{}
+	)
+	for _, action := range actions {
+		if action.Type != types.ActionTypeEnumForward {
+			continue
+		}
+		if action.ForwardConfig != nil {
+			for _, tg := range action.ForwardConfig.TargetGroups {
+				if tg.TargetGroupArn == nil || seen[*tg.TargetGroupArn] {
+					continue
+				}
+				seen[*tg.TargetGroupArn] = true
+				tuples = append(tuples, tg)
+			}
+		}
+		if action.TargetGroupArn != nil && !seen[*action.TargetGroupArn] {
+			seen[*action.TargetGroupArn] = true
+			tuples = append(tuples, types.TargetGroupTuple{TargetGroupArn: action.TargetGroupArn})
+		}
+	}
+	return tuples
+}
+
+func (s *Service) openSelectedRuleTargets() {
+	row, _ := s.ruleTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.rules) {
+		return
+	}
+	s.openTargetGroups(ruleOwner(s.rules[row-1], s.selectedListenerArn))
+}
+
+func (s *Service) openSelectedListenerTargets() {
+	row, _ := s.listenerTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.listeners) {
+		return
+	}
+	s.openTargetGroups(listenerOwner(s.listeners[row-1]))
+}
+
+// actionOwner identifies the rule or listener an action list belongs to.
+// kind says which of the two arn names, and from is the level the owner was
+// opened from, which is where Esc returns to.
+type actionOwner struct {
+	kind    tab
+	from    tab
+	arn     string
+	label   string
+	actions []types.Action
+}
+
+// ruleOwner maps the default rule to its listener: the default rule holds
+// the listener's default actions, which only ModifyListener can change.
+func ruleOwner(rule types.Rule, listenerArn string) actionOwner {
+	if isDefaultRule(rule) {
+		return actionOwner{kind: listenerTab, from: ruleTab, arn: listenerArn, label: "default rule", actions: rule.Actions}
+	}
+	label := fmt.Sprintf("rule %s", valueOr(rule.Priority))
+	return actionOwner{kind: ruleTab, from: ruleTab, arn: valueOr(rule.RuleArn), label: label, actions: rule.Actions}
+}
+
+func listenerOwner(listener types.Listener) actionOwner {
+	label := fmt.Sprintf("%s listener", listener.Protocol)
+	if listener.Port != nil {
+		label = fmt.Sprintf("%s:%d listener", listener.Protocol, *listener.Port)
+	}
+	return actionOwner{kind: listenerTab, from: listenerTab, arn: valueOr(listener.ListenerArn), label: label, actions: listener.DefaultActions}
+}
+
+// openTargetGroups drills from an action list into its forwarded target
+// groups.
+func (s *Service) openTargetGroups(owner actionOwner) {
+	tuples := forwardedTargetGroups(owner.actions)
+	if len(tuples) == 0 {
+		s.ctx.SetStatus("Selected action does not forward to a target group")
+		return
+	}
+
+	s.targetGroupOwner = owner
+	s.ctx.SetStatus("Fetching target groups...")
+	s.ctx.SetError(nil)
+
+	arns := make([]string, 0, len(tuples))
+	for _, tuple := range tuples {
+		arns = append(arns, *tuple.TargetGroupArn)
+	}
+
+	go func() {
+		groups, err := elbv2.DescribeTargetGroups(arns)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe target groups: %w", err))
+				return
+			}
+			byArn := make(map[string]types.TargetGroup, len(groups))
+			for _, group := range groups {
+				byArn[valueOr(group.TargetGroupArn)] = group
+			}
+
+			s.targetGroups = s.targetGroups[:0]
+			for _, tuple := range tuples {
+				entry := targetGroupEntry{arn: *tuple.TargetGroupArn, weight: tuple.Weight}
+				if group, ok := byArn[entry.arn]; ok {
+					entry.group = &group
+				}
+				s.targetGroups = append(s.targetGroups, entry)
+			}
+			s.loadTargetGroupMetrics()
+			s.renderTargetGroups()
+			s.showTargetGroupTab()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d target groups", len(s.targetGroups)))
+		})
+	}()
+}
+
+func (s *Service) openSelectedTargetGroup() {
+	row, _ := s.targetGroupTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.targetGroups) {
+		return
+	}
+	entry := s.targetGroups[row-1]
+	s.selectedTargetGroupArn = entry.arn
+	s.selectedTargetGroupName = elbv2.TargetGroupName(entry.arn)
+	s.loadTargetHealth(s.selectedTargetGroupArn)
+}
+
+func (s *Service) loadTargetHealth(targetGroupArn string) {
+	if targetGroupArn == "" {
+		return
+	}
+	s.ctx.SetStatus("Fetching target health...")
+	s.ctx.SetError(nil)
+
+	arn := targetGroupArn
+	go func() {
+		targets, err := elbv2.DescribeTargetHealth(&arn)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe target health: %w", err))
+				return
+			}
+			s.targets = targets
+			s.renderTargets()
+			s.showTargetTab()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d targets (%s)", len(targets), summarizeTargetHealth(targets)))
+		})
+	}()
+}
+
+func (s *Service) renderTargetGroups() {
+	table := s.targetGroupTable
+	table.Clear()
+
+	headers := append([]string{"Target group", "Weight", "Protocol", "Port", "Target type", "Health check"}, metricHeaders...)
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.targetGroups) == 0 {
+		table.SetCell(1, 0, tableCell("No target groups found").SetSelectable(false))
+		return
+	}
+
+	for idx, entry := range s.targetGroups {
+		weight := "-"
+		if entry.weight != nil {
+			weight = fmt.Sprintf("%d", *entry.weight)
+		}
+		protocol, port, targetType, healthCheck := "-", "-", "-", "-"
+		if group := entry.group; group != nil {
+			protocol = string(group.Protocol)
+			if group.Port != nil {
+				port = fmt.Sprintf("%d", *group.Port)
+			}
+			targetType = string(group.TargetType)
+			healthCheck = formatHealthCheck(group)
+		}
+
+		table.SetCell(idx+1, 0, tableCell(elbv2.TargetGroupName(entry.arn)))
+		table.SetCell(idx+1, 1, tableCell(weight))
+		table.SetCell(idx+1, 2, tableCell(protocol))
+		table.SetCell(idx+1, 3, tableCell(port))
+		table.SetCell(idx+1, 4, tableCell(targetType))
+		table.SetCell(idx+1, 5, tableCell(healthCheck))
+	}
+	s.renderTargetGroupMetrics()
+
+	table.Select(1, 0)
+}
+
+func (s *Service) renderTargets() {
+	table := s.targetTable
+	table.Clear()
+
+	headers := []string{"Target", "Port", "AZ", "State", "Reason", "Description"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.targets) == 0 {
+		table.SetCell(1, 0, tableCell("No registered targets").SetSelectable(false))
+		return
+	}
+
+	for idx, desc := range s.targets {
+		id, port, az := "-", "-", "-"
+		if target := desc.Target; target != nil {
+			id = valueOr(target.Id)
+			if target.Port != nil {
+				port = fmt.Sprintf("%d", *target.Port)
+			}
+			if target.AvailabilityZone != nil {
+				az = *target.AvailabilityZone
+			}
+		}
+		state, reason, description := "-", "-", "-"
+		if health := desc.TargetHealth; health != nil {
+			state = string(health.State)
+			if health.Reason != "" {
+				reason = string(health.Reason)
+			}
+			if health.Description != nil {
+				description = *health.Description
+			}
+		}
+
+		table.SetCell(idx+1, 0, tableCell(id))
+		table.SetCell(idx+1, 1, tableCell(port))
+		table.SetCell(idx+1, 2, tableCell(az))
+		table.SetCell(idx+1, 3, tableCell(state).SetTextColor(targetStateColor(desc.TargetHealth)))
+		table.SetCell(idx+1, 4, tableCell(reason))
+		table.SetCell(idx+1, 5, tableCell(description))
+	}
+
+	table.Select(1, 0)
+}
+
+func (s *Service) showTargetGroupTab() {
+	s.current = targetGroupTab
+	title := "Target groups"
+	if s.targetGroupOwner.label != "" {
+		title = fmt.Sprintf("Target groups for %s", s.targetGroupOwner.label)
+	}
+	s.targetGroupTable.SetTitle(title)
+	s.pages.SwitchToPage("targetgroups")
+	s.setFocus(s.targetGroupTable)
+}
+
+func (s *Service) showTargetTab() {
+	s.current = targetTab
+	title := "Targets"
+	if s.selectedTargetGroupName != "" {
+		title = fmt.Sprintf("Targets in %s", s.selectedTargetGroupName)
+	}
+	s.targetTable.SetTitle(title)
+	s.pages.SwitchToPage("targets")
+	s.setFocus(s.targetTable)
+}
+
+func formatHealthCheck(group *types.TargetGroup) string {
+	if group.HealthCheckEnabled != nil && !*group.HealthCheckEnabled {
+		return "disabled"
+	}
+	check := string(group.HealthCheckProtocol)
+	if group.HealthCheckPort != nil {
+		check = fmt.Sprintf("%s:%s", check, *group.HealthCheckPort)
+	}
+	if group.HealthCheckPath != nil {
+		check += *group.HealthCheckPath
+	}
+	return check
+}
+
+func targetStateColor(health *types.TargetHealth) tcell.Color {
+	if health == nil {
+		return tcell.ColorGray
+	}
+	switch health.State {
+	case types.TargetHealthStateEnumHealthy:
+		return tcell.ColorGreen
+	case types.TargetHealthStateEnumUnhealthy, types.TargetHealthStateEnumUnhealthyDraining:
+		return tcell.ColorRed
+	case types.TargetHealthStateEnumInitial, types.TargetHealthStateEnumDraining:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorGray
+	}
+}
+
+func summarizeTargetHealth(targets []types.TargetHealthDescription) string {
+	counts := map[types.TargetHealthStateEnum]int{}
+	for _, desc := range targets {
+		if desc.TargetHealth != nil {
+			counts[desc.TargetHealth.State]++
+		}
+	}
+	if len(counts) == 0 {
+		return "no health data"
+	}
+	parts := []string{}
+	for _, state := range types.TargetHealthStateEnumHealthy.Values() {
+		if n := counts[state]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, state))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
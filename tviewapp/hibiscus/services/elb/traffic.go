@@ -0,0 +1,391 @@
+package elb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/internal/aws/elbv2"
+)
+
+const (
+	defaultShiftSteps = 4
+	defaultShiftPause = 30 * time.Second
+	shiftBarWidth     = 30
+)
+
+// trafficShift is the state of the weighted-forward view for one rule or
+// listener. It outlives the modal so a stepwise shift keeps running (and can
+// be reopened) after the view is closed.
+type trafficShift struct {
+	owner   actionOwner
+	order   []string
+	current elbv2.Weights
+	pending elbv2.Weights
+
+	running bool
+	cancel  chan struct{}
+}
+
+func (s *Service) openSelectedTrafficShift() {
+	var owner actionOwner
+	switch s.current {
+	case ruleTab:
+		rule, ok := s.selectedRule()
+		if !ok {
+			return
+		}
+		owner = ruleOwner(rule, s.selectedListenerArn)
+	case listenerTab:
+		row, _ := s.listenerTable.GetSelection()
+		if row <= 0 || row-1 >= len(s.listeners) {
+			return
+		}
+		owner = listenerOwner(s.listeners[row-1])
+	case targetGroupTab:
+		owner = s.targetGroupOwner
+	default:
+		return
+	}
+	s.openTrafficShift(owner)
+}
+
+func (s *Service) openTrafficShift(owner actionOwner) {
+	shift := s.shift
+	if shift == nil || shift.owner.arn != owner.arn || !shift.running {
+		weights, ok := elbv2.ForwardWeights(owner.actions)
+		if !ok {
+			s.ctx.SetStatus("Selected action does not forward to a target group")
+			return
+		}
+		order := make([]string, 0, len(weights))
+		for _, tuple := range forwardedTargetGroups(owner.actions) {
+			order = append(order, *tuple.TargetGroupArn)
+		}
+		shift = &trafficShift{owner: owner, order: order, current: weights, pending: weights.Clone()}
+		s.shift = shift
+	}
+
+	table := buildTable("")
+	info := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+
+	stepsInput := tview.NewInputField().
+		SetLabel("Steps: ").
+		SetText(strconv.Itoa(defaultShiftSteps)).
+		SetFieldWidth(4).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	pauseInput := tview.NewInputField().
+		SetLabel("Pause between steps (s): ").
+		SetText(strconv.Itoa(int(defaultShiftPause.Seconds()))).
+		SetFieldWidth(6).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	form := tview.NewForm().
+		SetHorizontal(true).
+		AddFormItem(stepsInput).
+		AddFormItem(pauseInput)
+
+	render := func() {
+		renderTrafficShift(table, info, shift, s.shiftHistory[shift.owner.arn])
+	}
+	s.shiftRender = render
+	render()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := table.GetSelection()
+		arn := ""
+		if row > 0 && row-1 < len(shift.order) {
+			arn = shift.order[row-1]
+		}
+
+		switch event.Key() {
+		case tcell.KeyTab:
+			if s.ctx.App != nil {
+				s.ctx.App.SetFocus(form)
+			}
+			return nil
+		case tcell.KeyLeft:
+			adjustWeight(shift, arn, -5)
+			render()
+			return nil
+		case tcell.KeyRight:
+			adjustWeight(shift, arn, 5)
+			render()
+			return nil
+		}
+
+		switch event.Rune() {
+		case '-':
+			adjustWeight(shift, arn, -1)
+		case '+', '=':
+			adjustWeight(shift, arn, 1)
+		case '0':
+			adjustWeight(shift, arn, -elbv2.MaxTargetGroupWeight)
+		case 'f', 'F':
+			// Send everything to the selected target group.
+			if arn != "" {
+				total := max(shift.current.Total(), 1)
+				for _, other := range shift.order {
+					shift.pending[other] = 0
+				}
+				shift.pending[arn] = min(total, elbv2.MaxTargetGroupWeight)
+			}
+		case 'c', 'C':
+			shift.pending = shift.current.Clone()
+		case 'a', 'A':
+			s.startShift(shift, shift.pending.Clone(), 1, 0)
+		case 's', 'S':
+			steps, err := strconv.Atoi(stepsInput.GetText())
+			if err != nil || steps < 1 {
+				s.ctx.SetError(fmt.Errorf("steps must be a positive number"))
+				return nil
+			}
+			pause, err := strconv.Atoi(pauseInput.GetText())
+			if err != nil || pause < 0 {
+				s.ctx.SetError(fmt.Errorf("pause must be a non-negative number of seconds"))
+				return nil
+			}
+			s.startShift(shift, shift.pending.Clone(), steps, time.Duration(pause)*time.Second)
+		case 'u', 'U':
+			s.rollbackShift(shift)
+		case 'x', 'X':
+			s.stopShift(shift)
+		default:
+			return event
+		}
+		render()
+		return nil
+	})
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyBacktab && s.ctx.App != nil {
+			s.ctx.App.SetFocus(table)
+			return nil
+		}
+		return event
+	})
+	form.SetCancelFunc(s.closeModal)
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(info, 4, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(form, 3, 0, false)
+	layout.SetBorder(true)
+	layout.SetTitle(fmt.Sprintf("Traffic shift – forward action of %s", owner.label))
+	layout.SetTitleAlign(tview.AlignLeft)
+
+	s.showModal(trafficModalPageName, centerPrimitive(layout, 110, 24))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(table)
+	}
+}
+
+func adjustWeight(shift *trafficShift, arn string, delta int32) {
+	if arn == "" {
+		return
+	}
+	shift.pending[arn] = min(max(shift.pending[arn]+delta, 0), elbv2.MaxTargetGroupWeight)
+}
+
+// startShift applies target either in one step or spread over steps with a
+// pause in between. The weights in effect before the shift become the
+// rollback point.
+func (s *Service) startShift(shift *trafficShift, target elbv2.Weights, steps int, pause time.Duration) {
+	if shift.running {
+		s.ctx.SetStatus("A traffic shift is already running; press x to stop it first")
+		return
+	}
+	if target.Equal(shift.current) {
+		s.ctx.SetStatus("Weights are unchanged")
+		return
+	}
+	if target.Total() == 0 {
+		s.ctx.SetError(fmt.Errorf("at least one target group needs a non-zero weight"))
+		return
+	}
+	s.ctx.SetError(nil)
+
+	s.shiftHistory[shift.owner.arn] = shift.current.Clone()
+	plan := elbv2.ShiftSteps(shift.current, target, steps)
+	owner := shift.owner
+	order := shift.order
+	cancel := make(chan struct{})
+	shift.running = true
+	shift.cancel = cancel
+
+	go func() {
+		defer s.ctx.App.QueueUpdateDraw(func() {
+			shift.running = false
+			s.renderShift()
+		})
+
+		for idx, weights := range plan {
+			if idx > 0 {
+				s.ctx.App.QueueUpdateDraw(func() {
+					s.ctx.SetStatus(fmt.Sprintf("Traffic shift step %d/%d applied; next step in %s (x to stop)", idx, len(plan), pause))
+				})
+				select {
+				case <-cancel:
+					s.ctx.App.QueueUpdateDraw(func() {
+						s.ctx.SetStatus(fmt.Sprintf("Traffic shift stopped after step %d/%d", idx, len(plan)))
+					})
+					return
+				case <-time.After(pause):
+				}
+			}
+
+			actions := elbv2.WithForwardWeights(owner.actions, weights, order)
+			err := applyOwnerActions(owner, actions)
+			step := idx + 1
+			s.ctx.App.QueueUpdateDraw(func() {
+				if err != nil {
+					s.ctx.SetError(fmt.Errorf("traffic shift step %d/%d: %w", step, len(plan), err))
+					return
+				}
+				shift.current = weights
+				shift.owner.actions = actions
+				s.syncOwnerActions(owner.arn, actions)
+				s.renderShift()
+				s.ctx.SetStatus(fmt.Sprintf("Traffic shift step %d/%d: %s", step, len(plan), weights.Describe(order)))
+			})
+			if err != nil {
+				return
+			}
+		}
+	}()
+}
+
+func (s *Service) stopShift(shift *trafficShift) {
+	if !shift.running || shift.cancel == nil {
+		s.ctx.SetStatus("No traffic shift is running")
+		return
+	}
+	close(shift.cancel)
+	shift.cancel = nil
+}
+
+// rollbackShift restores the weights that were in effect before the last
+// shift in a single step.
+func (s *Service) rollbackShift(shift *trafficShift) {
+	previous, ok := s.shiftHistory[shift.owner.arn]
+	if !ok {
+		s.ctx.SetStatus("Nothing to roll back")
+		return
+	}
+	if shift.running {
+		s.stopShift(shift)
+		s.ctx.SetStatus("Stopping the running shift; press u again to roll back")
+		return
+	}
+	shift.pending = previous.Clone()
+	s.startShift(shift, previous, 1, 0)
+}
+
+func (s *Service) renderShift() {
+	if s.shiftRender != nil && s.activeModal == trafficModalPageName {
+		s.shiftRender()
+	}
+}
+
+func applyOwnerActions(owner actionOwner, actions []types.Action) error {
+	arn := owner.arn
+	if owner.kind == listenerTab {
+		return elbv2.ModifyListenerDefaultActions(&arn, actions)
+	}
+	return elbv2.ModifyRuleActions(&arn, actions)
+}
+
+// syncOwnerActions updates the cached rule or listener after its actions were
+// changed so the tables reflect the new weights without a reload. A
+// listener's default actions are also those of its default rule.
+func (s *Service) syncOwnerActions(arn string, actions []types.Action) {
+	for idx := range s.rules {
+		rule := s.rules[idx]
+		if valueOr(rule.RuleArn) == arn || (isDefaultRule(rule) && s.selectedListenerArn == arn) {
+			s.rules[idx].Actions = actions
+			s.selectRuleArn = valueOr(rule.RuleArn)
+			s.renderRules()
+		}
+	}
+	for idx := range s.listeners {
+		if valueOr(s.listeners[idx].ListenerArn) == arn {
+			s.listeners[idx].DefaultActions = actions
+			row, _ := s.listenerTable.GetSelection()
+			s.renderListeners()
+			s.listenerTable.Select(row, 0)
+		}
+	}
+	if s.targetGroupOwner.arn == arn {
+		s.targetGroupOwner.actions = actions
+		weights, _ := elbv2.ForwardWeights(actions)
+		for idx := range s.targetGroups {
+			if weight, ok := weights[s.targetGroups[idx].arn]; ok {
+				w := weight
+				s.targetGroups[idx].weight = &w
+			}
+		}
+		row, _ := s.targetGroupTable.GetSelection()
+		s.renderTargetGroups()
+		s.targetGroupTable.Select(row, 0)
+	}
+}
+
+func renderTrafficShift(table *tview.Table, info *tview.TextView, shift *trafficShift, rollback elbv2.Weights) {
+	row, _ := table.GetSelection()
+	table.Clear()
+
+	headers := []string{"Target group", "Current", "Pending", "Share", ""}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	total := shift.pending.Total()
+	for idx, arn := range shift.order {
+		current, pending := shift.current[arn], shift.pending[arn]
+		share := 0.0
+		if total > 0 {
+			share = float64(pending) / float64(total)
+		}
+		pendingCell := tableCell(strconv.Itoa(int(pending)))
+		if pending != current {
+			pendingCell.SetTextColor(tcell.ColorYellow)
+		}
+		bar := strings.Repeat("█", int(share*shiftBarWidth+0.5))
+
+		table.SetCell(idx+1, 0, tableCell(elbv2.TargetGroupName(arn)))
+		table.SetCell(idx+1, 1, tableCell(strconv.Itoa(int(current))))
+		table.SetCell(idx+1, 2, pendingCell)
+		table.SetCell(idx+1, 3, tableCell(fmt.Sprintf("%.0f%%", share*100)))
+		table.SetCell(idx+1, 4, tableCell(bar).SetTextColor(tcell.ColorGreen))
+	}
+	table.Select(min(max(row, 1), len(shift.order)), 0)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[lightcyan]Stickiness[-] %s", formatStickiness(elbv2.ForwardStickiness(shift.owner.actions)))
+	if rollback != nil {
+		fmt.Fprintf(&b, "   [lightcyan]Rollback to[-] %s", tview.Escape(rollback.Describe(shift.order)))
+	}
+	if shift.running {
+		b.WriteString("   [yellow]shift in progress[-]")
+	}
+	b.WriteString("\n[gray]←/→ ±5  -/+ ±1  0 zero  f all traffic to selected  c reset  a apply  s apply stepwise  x stop  u roll back  Tab steps/pause[-]")
+	info.SetText(b.String())
+}
+
+func formatStickiness(config *types.TargetGroupStickinessConfig) string {
+	if config == nil || config.Enabled == nil || !*config.Enabled {
+		return "disabled"
+	}
+	if config.DurationSeconds != nil {
+		return fmt.Sprintf("enabled, %s", time.Duration(*config.DurationSeconds)*time.Second)
+	}
+	return "enabled"
+}
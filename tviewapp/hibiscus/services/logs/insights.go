@@ -0,0 +1,800 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/jaehong21/hibiscus/config"
+	awslogs "github.com/jaehong21/hibiscus/internal/aws/logs"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+const defaultInsightsQuery = "fields @timestamp, @message, @logStream\n| sort @timestamp desc\n| limit 100"
+
+const (
+	queryPollInterval = time.Second
+	spinnerInterval   = 150 * time.Millisecond
+	// customTimeLayout is how custom range bounds are typed, in local time.
+	customTimeLayout = "2006-01-02 15:04"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// timeRange is a relative query range; the last entry is the custom range
+// typed into the From and To fields.
+type timeRange struct {
+	key      string
+	label    string
+	duration time.Duration
+}
+
+var timeRanges = []timeRange{
+	{key: "5m", label: "Last 5 minutes", duration: 5 * time.Minute},
+	{key: "15m", label: "Last 15 minutes", duration: 15 * time.Minute},
+	{key: "1h", label: "Last hour", duration: time.Hour},
+	{key: "3h", label: "Last 3 hours", duration: 3 * time.Hour},
+	{key: "12h", label: "Last 12 hours", duration: 12 * time.Hour},
+	{key: "1d", label: "Last day", duration: 24 * time.Hour},
+	{key: "3d", label: "Last 3 days", duration: 3 * 24 * time.Hour},
+	{key: "1w", label: "Last week", duration: 7 * 24 * time.Hour},
+	{key: "custom", label: "Custom (From/To)"},
+}
+
+const defaultTimeRange = 2
+
+// queryDraft is the editor's content, kept while the editor is closed.
+type queryDraft struct {
+	query    string
+	groups   string
+	rangeIdx int
+	from     string
+	to       string
+}
+
+func (d queryDraft) groupNames() []string {
+	var names []string
+	for _, name := range strings.Split(d.groups, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (d queryDraft) timeRange(now time.Time) (time.Time, time.Time, error) {
+	if r := timeRanges[d.rangeIdx]; r.duration > 0 {
+		return now.Add(-r.duration), now, nil
+	}
+
+	from, err := time.ParseInLocation(customTimeLayout, strings.TrimSpace(d.from), time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from: expected %s", customTimeLayout)
+	}
+	to := now
+	if text := strings.TrimSpace(d.to); text != "" {
+		if to, err = time.ParseInLocation(customTimeLayout, text, time.Local); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to: expected %s", customTimeLayout)
+		}
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+	return from, to, nil
+}
+
+// insightsRun is a started query and the results received so far.
+type insightsRun struct {
+	draft      queryDraft
+	groups     []string
+	start, end time.Time
+	id         string
+	results    *awslogs.QueryResults
+	failed     bool
+
+	columns []string
+	rows    []map[string]string
+	sortCol int
+	sortAsc bool
+
+	stop chan struct{}
+}
+
+func (r *insightsRun) running() bool {
+	return !r.failed && (r.results == nil || !r.results.Done())
+}
+
+func (s *Service) toggleGroupMark() {
+	group, ok := s.selectedGroup()
+	if !ok {
+		return
+	}
+	name := valueOr(group.LogGroupName)
+	if s.markedGroups[name] {
+		delete(s.markedGroups, name)
+	} else {
+		s.markedGroups[name] = true
+	}
+	row, _ := s.groupTable.GetSelection()
+	s.renderGroups()
+	s.groupTable.Select(min(row+1, len(s.filteredGroups)), 0)
+	s.ctx.SetStatus(fmt.Sprintf("%d log groups marked – i queries them", len(s.markedGroups)))
+}
+
+// openQueryEditor opens the Logs Insights editor. It searches the marked
+// groups, else the groups of the previous query, else the group in view.
+func (s *Service) openQueryEditor() {
+	draft := s.draft
+	switch {
+	case len(s.markedGroups) > 0:
+		names := make([]string, 0, len(s.markedGroups))
+		for name := range s.markedGroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		draft.groups = strings.Join(names, ", ")
+	case draft.groups != "":
+	case s.current == tailTab && s.tail != nil:
+		draft.groups = s.tail.filter.GroupName
+	case s.current == streamTab:
+		draft.groups = s.currentGroup
+	default:
+		if group, ok := s.selectedGroup(); ok {
+			draft.groups = valueOr(group.LogGroupName)
+		}
+	}
+	s.draft = draft
+
+	saved, err := config.LoadSavedQueries()
+	if err != nil {
+		s.ctx.SetError(fmt.Errorf("load saved queries: %w", err))
+	}
+
+	queryArea := tview.NewTextArea().
+		SetLabel("Query").
+		SetText(draft.query, true)
+	queryArea.SetSize(10, 0)
+	queryArea.SetChangedFunc(func() {
+		s.draft.query = queryArea.GetText()
+	})
+
+	groupsInput := tview.NewInputField().
+		SetLabel("Log groups").
+		SetPlaceholder("comma-separated; Space marks groups in the group list").
+		SetText(draft.groups).
+		SetChangedFunc(func(text string) {
+			s.draft.groups = text
+		})
+	fromInput := tview.NewInputField().
+		SetLabel("From").
+		SetPlaceholder(customTimeLayout + " (custom range)").
+		SetText(draft.from).
+		SetChangedFunc(func(text string) {
+			s.draft.from = text
+		})
+	toInput := tview.NewInputField().
+		SetLabel("To").
+		SetPlaceholder("empty means now").
+		SetText(draft.to).
+		SetChangedFunc(func(text string) {
+			s.draft.to = text
+		})
+
+	labels := make([]string, 0, len(timeRanges))
+	for _, r := range timeRanges {
+		labels = append(labels, r.label)
+	}
+	rangeDropDown := tview.NewDropDown().
+		SetLabel("Time range").
+		SetOptions(labels, func(_ string, idx int) {
+			s.draft.rangeIdx = idx
+		}).
+		SetCurrentOption(draft.rangeIdx)
+
+	savedNames := []string{"(none)"}
+	for _, query := range saved {
+		savedNames = append(savedNames, query.Name)
+	}
+	savedDropDown := tview.NewDropDown().
+		SetLabel("Saved query").
+		SetOptions(savedNames, nil).
+		SetCurrentOption(0)
+	savedDropDown.SetSelectedFunc(func(_ string, idx int) {
+		if idx <= 0 || idx > len(saved) {
+			return
+		}
+		query := saved[idx-1]
+		s.savedName = query.Name
+		s.draft.query = query.Query
+		queryArea.SetText(query.Query, true)
+		if len(query.LogGroups) > 0 {
+			s.draft.groups = strings.Join(query.LogGroups, ", ")
+			groupsInput.SetText(s.draft.groups)
+		}
+		for rangeIdx, r := range timeRanges {
+			if r.key == query.Range && r.duration > 0 {
+				rangeDropDown.SetCurrentOption(rangeIdx)
+			}
+		}
+	})
+
+	form := tview.NewForm().
+		AddFormItem(savedDropDown).
+		AddFormItem(queryArea).
+		AddFormItem(groupsInput).
+		AddFormItem(rangeDropDown).
+		AddFormItem(fromInput).
+		AddFormItem(toInput)
+
+	form.AddButton("Run", func() {
+		s.closeModal()
+		s.runQuery(s.draft)
+	})
+	form.AddButton("Save", func() {
+		s.openSaveQueryForm()
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Logs Insights query")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+	form.SetFocus(1)
+
+	s.showModal(queryModalPageName, centerPrimitive(form, 120, 28))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+// openSaveQueryForm asks for a name and saves the editor's query, then
+// returns to the editor.
+func (s *Service) openSaveQueryForm() {
+	nameInput := tview.NewInputField().
+		SetLabel("Name: ").
+		SetText(s.savedName)
+
+	form := tview.NewForm().
+		AddFormItem(nameInput)
+
+	form.AddButton("Save", func() {
+		name := strings.TrimSpace(nameInput.GetText())
+		if name == "" {
+			s.ctx.SetError(fmt.Errorf("name is required"))
+			return
+		}
+		if strings.TrimSpace(s.draft.query) == "" {
+			s.ctx.SetError(fmt.Errorf("query is empty"))
+			return
+		}
+		query := config.SavedQuery{
+			Name:      name,
+			Query:     s.draft.query,
+			LogGroups: s.draft.groupNames(),
+		}
+		if r := timeRanges[s.draft.rangeIdx]; r.duration > 0 {
+			query.Range = r.key
+		}
+		if err := config.SaveQuery(query); err != nil {
+			s.ctx.SetError(fmt.Errorf("save query: %w", err))
+			return
+		}
+		s.savedName = name
+		s.ctx.SetError(nil)
+		s.ctx.SetStatus(fmt.Sprintf("Saved query %q", name))
+		s.openQueryEditor()
+	})
+	form.AddButton("Cancel", func() {
+		s.openQueryEditor()
+	})
+
+	form.SetCancelFunc(s.openQueryEditor)
+	form.SetTitle("Save query")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(queryModalPageName, centerPrimitive(form, 70, 7))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) runQuery(draft queryDraft) {
+	groups := draft.groupNames()
+	if len(groups) == 0 {
+		s.ctx.SetError(fmt.Errorf("select at least one log group"))
+		return
+	}
+	if strings.TrimSpace(draft.query) == "" {
+		s.ctx.SetError(fmt.Errorf("query is empty"))
+		return
+	}
+	start, end, err := draft.timeRange(time.Now())
+	if err != nil {
+		s.ctx.SetError(err)
+		return
+	}
+
+	s.cancelQuery()
+	run := &insightsRun{
+		draft:   draft,
+		groups:  groups,
+		start:   start,
+		end:     end,
+		sortCol: -1,
+		stop:    make(chan struct{}),
+	}
+	s.insights = run
+	if s.current != resultTab {
+		s.resultReturn = s.current
+	}
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus("Starting query...")
+	s.renderResults()
+	s.showResultTab()
+
+	go func() {
+		id, err := awslogs.StartQuery(groups, draft.query, start, end)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if run != s.insights {
+				// Cancelled while starting: stop it now that there is an
+				// ID to stop.
+				if err == nil {
+					stopQuery(id)
+				}
+				return
+			}
+			if err != nil {
+				run.failed = true
+				s.ctx.SetError(fmt.Errorf("start query: %w", err))
+				s.renderResults()
+				return
+			}
+			run.id = id
+			s.pollQuery(run)
+		})
+	}()
+}
+
+// pollQuery polls the query until it is done or cancelled, animating a
+// spinner in the status bar in between.
+func (s *Service) pollQuery(run *insightsRun) {
+	id, stop := run.id, run.stop
+	go func() {
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+
+		frame := 0
+		var nextPoll time.Time
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			frame++
+			if time.Now().Before(nextPoll) {
+				spinner := spinnerFrames[frame%len(spinnerFrames)]
+				s.ctx.App.QueueUpdateDraw(func() {
+					if run == s.insights && run.running() {
+						s.ctx.SetStatus(queryStatus(spinner, run))
+					}
+				})
+				continue
+			}
+
+			results, err := awslogs.GetQueryResults(&id)
+			nextPoll = time.Now().Add(queryPollInterval)
+			s.ctx.App.QueueUpdateDraw(func() {
+				s.receiveResults(run, results, err)
+			})
+			if err != nil || results.Done() {
+				return
+			}
+		}
+	}()
+}
+
+func (s *Service) receiveResults(run *insightsRun, results *awslogs.QueryResults, err error) {
+	if run != s.insights {
+		return
+	}
+	if err != nil {
+		run.failed = true
+		s.ctx.SetError(fmt.Errorf("get query results: %w", err))
+		s.renderResults()
+		return
+	}
+
+	run.results = results
+	run.columns, run.rows = resultRows(results)
+	s.sortResults()
+	s.renderResults()
+	if results.Done() {
+		s.ctx.SetStatus(queryStatus("", run))
+	}
+}
+
+// cancelQuery stops polling the current query and stops it on the service
+// side when it is still running. A query that is still starting is stopped
+// by runQuery once StartQuery returns its ID.
+func (s *Service) cancelQuery() {
+	run := s.insights
+	if run == nil {
+		return
+	}
+	close(run.stop)
+	s.insights = nil
+	if !run.running() {
+		return
+	}
+	if run.id != "" {
+		stopQuery(run.id)
+	}
+	s.ctx.SetStatus("Query cancelled")
+}
+
+func stopQuery(id string) {
+	go func() {
+		// The query is abandoned either way; a failed stop only means it
+		// runs to completion on the service side.
+		_ = awslogs.StopQuery(&id)
+	}()
+}
+
+func (s *Service) closeResults() {
+	s.cancelQuery()
+	switch s.resultReturn {
+	case tailTab:
+		if s.tail != nil {
+			s.showTailTab()
+			return
+		}
+	case streamTab:
+		s.showStreamTab()
+		return
+	}
+	s.showGroupTab()
+}
+
+func (s *Service) rerunQuery() {
+	if s.insights == nil {
+		return
+	}
+	s.runQuery(s.insights.draft)
+}
+
+// resultRows turns the result fields into rows keyed by field name. Columns
+// follow the order fields first appear in; the internal @ptr field is left
+// out.
+func resultRows(results *awslogs.QueryResults) ([]string, []map[string]string) {
+	var columns []string
+	known := map[string]bool{}
+	rows := make([]map[string]string, 0, len(results.Rows))
+	for _, fields := range results.Rows {
+		row := map[string]string{}
+		for _, field := range fields {
+			name := valueOr(field.Field)
+			if name == "" || name == "@ptr" {
+				continue
+			}
+			if !known[name] {
+				known[name] = true
+				columns = append(columns, name)
+			}
+			row[name] = valueOr(field.Value)
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+// toggleSort sorts by the selected column, flipping the direction when it
+// is already the sort column.
+func (s *Service) toggleSort() {
+	run := s.insights
+	if run == nil || len(run.columns) == 0 {
+		return
+	}
+	row, col := s.resultTable.GetSelection()
+	if col < 0 || col >= len(run.columns) {
+		return
+	}
+	if run.sortCol == col {
+		run.sortAsc = !run.sortAsc
+	} else {
+		run.sortCol, run.sortAsc = col, true
+	}
+	s.sortResults()
+	s.renderResults()
+	s.resultTable.Select(row, col)
+}
+
+func (s *Service) sortResults() {
+	run := s.insights
+	if run == nil || run.sortCol < 0 || run.sortCol >= len(run.columns) {
+		return
+	}
+	column := run.columns[run.sortCol]
+	sort.SliceStable(run.rows, func(i, j int) bool {
+		a, b := run.rows[i][column], run.rows[j][column]
+		if run.sortAsc {
+			return lessValue(a, b)
+		}
+		return lessValue(b, a)
+	})
+}
+
+// lessValue compares numerically when both values are numbers.
+func lessValue(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
+
+func (s *Service) renderResults() {
+	table := s.resultTable
+	table.Clear()
+
+	run := s.insights
+	if run == nil {
+		return
+	}
+
+	title := fmt.Sprintf("Insights: %s", strings.Join(run.groups, ", "))
+	switch {
+	case run.failed:
+		title += " (failed)"
+	case run.results == nil:
+		title += " (starting)"
+	default:
+		title += fmt.Sprintf(" (%s, %d rows)", strings.ToLower(string(run.results.Status)), len(run.rows))
+	}
+	table.SetTitle(title)
+
+	if len(run.columns) == 0 {
+		msg := "Waiting for results..."
+		if run.failed || (run.results != nil && run.results.Done()) {
+			msg = "No results"
+		}
+		table.SetCell(0, 0, headerCell(""))
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	for col, column := range run.columns {
+		if col == run.sortCol {
+			if run.sortAsc {
+				column += " ▲"
+			} else {
+				column += " ▼"
+			}
+		}
+		table.SetCell(0, col, headerCell(column))
+	}
+	for idx, row := range run.rows {
+		for col, column := range run.columns {
+			value := strings.ReplaceAll(row[column], "\n", " ")
+			table.SetCell(idx+1, col, tableCell(value).SetMaxWidth(80))
+		}
+	}
+
+	if selected, _ := table.GetSelection(); selected <= 0 || selected > len(run.rows) {
+		table.Select(1, 0)
+	}
+}
+
+func (s *Service) openRecordDetail() {
+	run := s.insights
+	if run == nil {
+		return
+	}
+	row, _ := s.resultTable.GetSelection()
+	if row <= 0 || row-1 >= len(run.rows) {
+		return
+	}
+	record := run.rows[row-1]
+
+	var b strings.Builder
+	for _, column := range run.columns {
+		value, ok := record[column]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "[lightcyan]%s[-]\n%s\n\n", tview.Escape(column), tview.Escape(s.formatMessage(value)))
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(b.String())
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Record %d of %d", row, len(run.rows)))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(recordModalPageName, centerPrimitive(view, 120, 30))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+}
+
+func (s *Service) openExportForm() {
+	run := s.insights
+	if run == nil || len(run.rows) == 0 {
+		s.ctx.SetStatus("No results to export")
+		return
+	}
+
+	base := filepath.Join(saveDir(), fmt.Sprintf("insights-%s", time.Now().Format("20060102-150405")))
+	pathInput := tview.NewInputField().
+		SetLabel("Local path: ").
+		SetText(base + ".csv")
+	formats := []string{"CSV", "JSON"}
+	format := 0
+	formatDropDown := tview.NewDropDown().
+		SetLabel("Format: ").
+		SetOptions(formats, func(text string, idx int) {
+			format = idx
+			// Keep the extension in step with the format unless the path
+			// was changed by hand.
+			path := pathInput.GetText()
+			for _, other := range formats {
+				if ext := "." + strings.ToLower(other); strings.HasSuffix(path, ext) {
+					pathInput.SetText(strings.TrimSuffix(path, ext) + "." + strings.ToLower(text))
+				}
+			}
+		}).
+		SetCurrentOption(0)
+
+	form := tview.NewForm().
+		AddTextView("Rows", fmt.Sprintf("%d rows, %d columns", len(run.rows), len(run.columns)), 0, 1, false, false).
+		AddFormItem(formatDropDown).
+		AddFormItem(pathInput)
+
+	form.AddButton("Export", func() {
+		target := expandHome(strings.TrimSpace(pathInput.GetText()))
+		if target == "" {
+			s.ctx.SetError(fmt.Errorf("local path is required"))
+			return
+		}
+		s.closeModal()
+		if err := exportResults(run, formats[format], target); err != nil {
+			s.ctx.SetError(fmt.Errorf("export results: %w", err))
+			return
+		}
+		s.ctx.SetError(nil)
+		s.ctx.SetStatus(fmt.Sprintf("Exported %d rows to %s", len(run.rows), target))
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Export results")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(exportModalPageName, centerPrimitive(form, 100, 11))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+// exportResults writes the rows in their displayed order. JSON rows only
+// hold the fields the record has, in column order.
+func exportResults(run *insightsRun, format, target string) error {
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if format == "JSON" {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		rows := make([]orderedRow, len(run.rows))
+		for idx, row := range run.rows {
+			rows[idx] = orderedRow{columns: run.columns, values: row}
+		}
+		return encoder.Encode(rows)
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(run.columns); err != nil {
+		return err
+	}
+	for _, row := range run.rows {
+		record := make([]string, len(run.columns))
+		for col, column := range run.columns {
+			record[col] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// orderedRow marshals a result row as a JSON object whose keys follow the
+// column order; encoding/json would sort the keys of a map.
+type orderedRow struct {
+	columns []string
+	values  map[string]string
+}
+
+func (r orderedRow) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	first := true
+	for _, column := range r.columns {
+		value, ok := r.values[column]
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(encoded)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func queryStatus(spinner string, run *insightsRun) string {
+	var stats string
+	if run.results != nil && run.results.Statistics != nil {
+		st := run.results.Statistics
+		scanned := int64(st.BytesScanned)
+		stats = fmt.Sprintf("%.0f of %.0f records matched, %s scanned", st.RecordsMatched, st.RecordsScanned, utils.GetSizeFromByte(&scanned))
+	}
+	if spinner == "" {
+		status := "Query finished"
+		if run.results != nil {
+			status = fmt.Sprintf("Query %s", strings.ToLower(string(run.results.Status)))
+		}
+		if stats != "" {
+			status += ": " + stats
+		}
+		return status
+	}
+	if stats == "" {
+		return fmt.Sprintf("%s Running query...", spinner)
+	}
+	return fmt.Sprintf("%s Running query... %s", spinner, stats)
+}
@@ -0,0 +1,642 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awslogs "github.com/jaehong21/hibiscus/internal/aws/logs"
+	"github.com/jaehong21/hibiscus/tviewapp/hibiscus"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+type tab int
+
+const (
+	groupTab tab = iota
+	streamTab
+	tailTab
+	resultTab
+)
+
+const (
+	contentPageName     = "logs-content"
+	saveModalPageName   = "logs-save-modal"
+	queryModalPageName  = "logs-query-modal"
+	recordModalPageName = "logs-record-modal"
+	exportModalPageName = "logs-export-modal"
+)
+
+const groupTableTitle = "CloudWatch log groups"
+
+// Service implements the hibiscus.Service interface for CloudWatch Logs.
+type Service struct {
+	ctx hibiscus.ServiceContext
+
+	root        *tview.Pages
+	layout      *tview.Flex
+	pages       *tview.Pages
+	filter      *tview.InputField
+	groupTable  *tview.Table
+	streamTable *tview.Table
+	tailView    *tview.TextView
+	resultTable *tview.Table
+
+	current tab
+
+	groups          []types.LogGroup
+	filteredGroups  []types.LogGroup
+	groupQuery      string
+	streams         []types.LogStream
+	filteredStreams []types.LogStream
+	streamQuery     string
+	currentGroup    string
+
+	// tail is the running tail, if any; tailReturn is the tab Esc goes back to.
+	tail       *tailSession
+	tailReturn tab
+	prettyJSON bool
+
+	// markedGroups are the groups selected for a Logs Insights query.
+	markedGroups map[string]bool
+	draft        queryDraft
+	savedName    string
+	// insights is the last query started; resultReturn is the tab Esc goes
+	// back to from its results.
+	insights     *insightsRun
+	resultReturn tab
+
+	mu     sync.Mutex
+	active bool
+
+	activeModal string
+}
+
+func New(ctx hibiscus.ServiceContext) hibiscus.Service {
+	svc := &Service{
+		ctx:          ctx,
+		current:      groupTab,
+		markedGroups: map[string]bool{},
+		draft:        queryDraft{query: defaultInsightsQuery, rangeIdx: defaultTimeRange},
+	}
+
+	svc.filter = tview.NewInputField().
+		SetLabel("Filter (/): ").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+
+	svc.groupTable = buildTable(groupTableTitle)
+	svc.streamTable = buildTable("Log streams")
+	svc.tailView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	svc.tailView.SetBorder(true)
+	svc.tailView.SetTitleAlign(tview.AlignLeft)
+	svc.tailView.SetBorderColor(tcell.ColorDimGray)
+	svc.resultTable = buildTable("Insights results")
+	svc.resultTable.SetSelectable(true, true)
+
+	svc.pages = tview.NewPages()
+	svc.pages.AddPage("groups", svc.groupTable, true, true)
+	svc.pages.AddPage("streams", svc.streamTable, true, false)
+	svc.pages.AddPage("tail", svc.tailView, true, false)
+	svc.pages.AddPage("results", svc.resultTable, true, false)
+
+	svc.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(svc.filter, 1, 0, true).
+		AddItem(svc.pages, 0, 1, true)
+
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
+	svc.filter.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			svc.applyFilter(strings.TrimSpace(svc.filter.GetText()))
+		case tcell.KeyEsc:
+			svc.exitFilterMode()
+		}
+	})
+
+	return svc
+}
+
+func (s *Service) Name() string  { return "logs" }
+func (s *Service) Title() string { return "CloudWatch Logs – groups › streams › tail / insights" }
+func (s *Service) Primitive() tview.Primitive {
+	return s.root
+}
+
+func (s *Service) Init() {
+	s.loadGroups()
+}
+
+func (s *Service) Activate() {
+	s.active = true
+	if s.current == tailTab {
+		s.startPolling(s.tail)
+	}
+	s.focusCurrentTable()
+}
+
+// Deactivate stops polling while another service is shown; the tail picks up
+// where it left off when the view is activated again.
+func (s *Service) Deactivate() {
+	s.active = false
+	s.stopPolling(s.tail)
+}
+
+func (s *Service) Refresh() {
+	switch s.current {
+	case resultTab:
+		s.rerunQuery()
+	case tailTab:
+		s.restartTail()
+	case streamTab:
+		s.loadStreams(s.currentGroup)
+	default:
+		s.loadGroups()
+	}
+}
+
+func (s *Service) EnterFilterMode() bool {
+	if !s.canFocus() || s.modalVisible() {
+		return false
+	}
+	if s.current == tailTab && s.tail != nil {
+		// In the tail the filter sets the CloudWatch filter pattern.
+		s.filter.SetText(s.tail.filter.Pattern)
+	}
+	s.ctx.App.SetFocus(s.filter)
+	return true
+}
+
+// InFilterMode also reports true while a modal is open so typing into its
+// text fields does not trigger global shortcuts.
+func (s *Service) InFilterMode() bool {
+	return s.filter.HasFocus() || s.modalVisible()
+}
+
+func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event == nil {
+		return nil
+	}
+
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		if s.filter.HasFocus() {
+			s.exitFilterMode()
+			return nil
+		}
+		switch s.current {
+		case resultTab:
+			s.closeResults()
+			return nil
+		case tailTab:
+			s.closeTail()
+			return nil
+		case streamTab:
+			s.showGroupTab()
+			return nil
+		}
+	case tcell.KeyEnter:
+		if s.groupTable.HasFocus() {
+			s.openSelectedGroup()
+			return nil
+		}
+		if s.streamTable.HasFocus() {
+			s.tailSelectedStream()
+			return nil
+		}
+		if s.resultTable.HasFocus() {
+			s.openRecordDetail()
+			return nil
+		}
+	}
+
+	switch event.Rune() {
+	case 't':
+		if s.groupTable.HasFocus() {
+			if group, ok := s.selectedGroup(); ok {
+				s.openTail(valueOr(group.LogGroupName), "")
+			}
+			return nil
+		}
+		if s.streamTable.HasFocus() {
+			s.openTail(s.currentGroup, "")
+			return nil
+		}
+	case ' ':
+		if s.groupTable.HasFocus() {
+			s.toggleGroupMark()
+			return nil
+		}
+		if s.tailView.HasFocus() {
+			s.togglePause()
+			return nil
+		}
+	case 'i':
+		if s.groupTable.HasFocus() || s.streamTable.HasFocus() || s.tailView.HasFocus() || s.resultTable.HasFocus() {
+			s.openQueryEditor()
+			return nil
+		}
+	case 's':
+		if s.resultTable.HasFocus() {
+			s.toggleSort()
+			return nil
+		}
+	case 'x':
+		if s.resultTable.HasFocus() {
+			s.openExportForm()
+			return nil
+		}
+	case 'j':
+		if s.tailView.HasFocus() {
+			s.togglePrettyJSON()
+			return nil
+		}
+	case 'w':
+		if s.tailView.HasFocus() {
+			s.openSaveForm()
+			return nil
+		}
+	}
+
+	return event
+}
+
+func (s *Service) exitFilterMode() {
+	s.filter.SetText("")
+	if s.modalVisible() {
+		return
+	}
+	s.focusCurrentTable()
+}
+
+func (s *Service) loadGroups() {
+	s.ctx.SetStatus("Fetching log groups...")
+	s.ctx.SetError(nil)
+
+	go func() {
+		groups, err := awslogs.DescribeLogGroups()
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe log groups: %w", err))
+				return
+			}
+			sort.SliceStable(groups, func(i, j int) bool {
+				return valueOr(groups[i].LogGroupName) < valueOr(groups[j].LogGroupName)
+			})
+
+			s.mu.Lock()
+			s.groups = groups
+			s.mu.Unlock()
+			s.filterGroups()
+			s.renderGroups()
+			if s.current == groupTab {
+				s.showGroupTab()
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d log groups", len(groups)))
+		})
+	}()
+}
+
+func (s *Service) loadStreams(group string) {
+	if group == "" {
+		return
+	}
+	s.ctx.SetStatus(fmt.Sprintf("Fetching streams for %s...", group))
+	s.ctx.SetError(nil)
+
+	go func() {
+		streams, err := awslogs.DescribeLogStreams(&group)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("describe log streams: %w", err))
+				return
+			}
+			s.mu.Lock()
+			s.streams = streams
+			s.mu.Unlock()
+			if group != s.currentGroup {
+				s.currentGroup = group
+				s.streamQuery = ""
+				s.streamTable.Select(1, 0)
+			}
+			s.filterStreams()
+			s.renderStreams()
+			s.showStreamTab()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d streams", len(streams)))
+		})
+	}()
+}
+
+func (s *Service) openSelectedGroup() {
+	group, ok := s.selectedGroup()
+	if !ok {
+		return
+	}
+	s.loadStreams(valueOr(group.LogGroupName))
+}
+
+func (s *Service) tailSelectedStream() {
+	row, _ := s.streamTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredStreams) {
+		return
+	}
+	s.openTail(s.currentGroup, valueOr(s.filteredStreams[row-1].LogStreamName))
+}
+
+func (s *Service) applyFilter(query string) {
+	s.ctx.SetError(nil)
+
+	switch s.current {
+	case tailTab:
+		s.setTailPattern(query)
+	case streamTab:
+		s.streamQuery = query
+		s.filterStreams()
+		s.renderStreams()
+	default:
+		s.groupQuery = query
+		s.filterGroups()
+		s.renderGroups()
+	}
+
+	s.filter.SetText("")
+	s.exitFilterMode()
+}
+
+func (s *Service) filterGroups() {
+	query := strings.ToLower(s.groupQuery)
+	s.filteredGroups = s.filteredGroups[:0]
+	for _, group := range s.groups {
+		if strings.Contains(strings.ToLower(valueOr(group.LogGroupName)), query) {
+			s.filteredGroups = append(s.filteredGroups, group)
+		}
+	}
+}
+
+func (s *Service) filterStreams() {
+	query := strings.ToLower(s.streamQuery)
+	s.filteredStreams = s.filteredStreams[:0]
+	for _, stream := range s.streams {
+		if strings.Contains(strings.ToLower(valueOr(stream.LogStreamName)), query) {
+			s.filteredStreams = append(s.filteredStreams, stream)
+		}
+	}
+}
+
+func (s *Service) renderGroups() {
+	table := s.groupTable
+	table.Clear()
+
+	title := groupTableTitle
+	if s.groupQuery != "" {
+		title += fmt.Sprintf(" (filter: %s)", s.groupQuery)
+	}
+	if len(s.markedGroups) > 0 {
+		title += fmt.Sprintf(" – %d marked", len(s.markedGroups))
+	}
+	table.SetTitle(title)
+
+	headers := []string{"Log group", "Retention", "Stored", "Class", "Metric filters", "Created"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredGroups) == 0 {
+		msg := "No log groups found"
+		if len(s.groups) > 0 {
+			msg = "No log groups match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	row, _ := table.GetSelection()
+	for idx, group := range s.filteredGroups {
+		retention := "Never expire"
+		if group.RetentionInDays != nil {
+			retention = fmt.Sprintf("%d days", *group.RetentionInDays)
+		}
+		stored := "-"
+		if group.StoredBytes != nil {
+			stored = utils.GetSizeFromByte(group.StoredBytes)
+		}
+		filters := "0"
+		if group.MetricFilterCount != nil {
+			filters = fmt.Sprint(*group.MetricFilterCount)
+		}
+
+		name := valueOr(group.LogGroupName)
+		if s.markedGroups[name] {
+			name = "✓ " + name
+		}
+
+		table.SetCell(idx+1, 0, tableCell(name))
+		table.SetCell(idx+1, 1, tableCell(retention))
+		table.SetCell(idx+1, 2, tableCell(stored))
+		table.SetCell(idx+1, 3, tableCell(strings.ToLower(string(group.LogGroupClass))))
+		table.SetCell(idx+1, 4, tableCell(filters))
+		table.SetCell(idx+1, 5, tableCell(formatTime(awslogs.Time(group.CreationTime))))
+	}
+
+	if row <= 0 || row > len(s.filteredGroups) {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+func (s *Service) renderStreams() {
+	table := s.streamTable
+	table.Clear()
+
+	title := fmt.Sprintf("Streams in %s", s.currentGroup)
+	if len(s.streams) >= awslogs.StreamLimit {
+		title += fmt.Sprintf(" (%d most recent)", awslogs.StreamLimit)
+	}
+	if s.streamQuery != "" {
+		title += fmt.Sprintf(" (filter: %s)", s.streamQuery)
+	}
+	table.SetTitle(title)
+
+	headers := []string{"Log stream", "Last event", "Age", "First event", "Created"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredStreams) == 0 {
+		msg := "No log streams found"
+		if len(s.streams) > 0 {
+			msg = "No log streams match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	row, _ := table.GetSelection()
+	for idx, stream := range s.filteredStreams {
+		last := awslogs.Time(stream.LastEventTimestamp)
+		age := "-"
+		if last != nil {
+			age = utils.GetAgeFromTime(last)
+		}
+
+		table.SetCell(idx+1, 0, tableCell(valueOr(stream.LogStreamName)))
+		table.SetCell(idx+1, 1, tableCell(formatTime(last)))
+		table.SetCell(idx+1, 2, tableCell(age))
+		table.SetCell(idx+1, 3, tableCell(formatTime(awslogs.Time(stream.FirstEventTimestamp))))
+		table.SetCell(idx+1, 4, tableCell(formatTime(awslogs.Time(stream.CreationTime))))
+	}
+
+	if row <= 0 || row > len(s.filteredStreams) {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+func (s *Service) selectedGroup() (types.LogGroup, bool) {
+	row, _ := s.groupTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredGroups) {
+		return types.LogGroup{}, false
+	}
+	return s.filteredGroups[row-1], true
+}
+
+func (s *Service) showGroupTab() {
+	s.current = groupTab
+	s.filter.SetLabel("Filter (/): ")
+	s.pages.SwitchToPage("groups")
+	s.setFocus(s.groupTable)
+}
+
+func (s *Service) showStreamTab() {
+	s.current = streamTab
+	s.filter.SetLabel("Filter (/): ")
+	s.pages.SwitchToPage("streams")
+	s.setFocus(s.streamTable)
+}
+
+func (s *Service) showTailTab() {
+	s.current = tailTab
+	s.filter.SetLabel("Pattern (/): ")
+	s.pages.SwitchToPage("tail")
+	s.setFocus(s.tailView)
+}
+
+func (s *Service) showResultTab() {
+	s.current = resultTab
+	s.pages.SwitchToPage("results")
+	s.setFocus(s.resultTable)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func headerCell(title string) *tview.TableCell {
+	return tview.NewTableCell(title).
+		SetTextColor(tcell.ColorLightCyan).
+		SetSelectable(false).
+		SetAlign(tview.AlignLeft)
+}
+
+func tableCell(value string) *tview.TableCell {
+	return tview.NewTableCell(value).
+		SetExpansion(1)
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func buildTable(title string) *tview.Table {
+	tbl := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	tbl.SetBorder(true)
+	tbl.SetTitle(title)
+	tbl.SetBorderColor(tcell.ColorDimGray)
+	return tbl
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
+
+func (s *Service) canFocus() bool {
+	return s.ctx.App != nil && s.active
+}
+
+func (s *Service) setFocus(p tview.Primitive) {
+	if !s.canFocus() || p == nil {
+		return
+	}
+	s.ctx.App.SetFocus(p)
+}
+
+func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
+	switch s.current {
+	case resultTab:
+		s.setFocus(s.resultTable)
+	case tailTab:
+		s.setFocus(s.tailView)
+	case streamTab:
+		s.setFocus(s.streamTable)
+	default:
+		s.setFocus(s.groupTable)
+	}
+}
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
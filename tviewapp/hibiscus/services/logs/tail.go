@@ -0,0 +1,571 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/rivo/tview"
+
+	awslogs "github.com/jaehong21/hibiscus/internal/aws/logs"
+)
+
+const (
+	// tailLookback is how far back a new tail starts.
+	tailLookback = 5 * time.Minute
+	tailInterval = 2 * time.Second
+	// tailOverlap is how far before the newest event each poll starts, so
+	// events that reach CloudWatch late (usually from other streams of the
+	// group) are still picked up.
+	tailOverlap = 30 * time.Second
+	// tailPollLimit caps the events fetched by a single poll.
+	tailPollLimit = 10000
+	// tailBufferLimit is how many events the tail keeps; older ones are
+	// dropped, in chunks so the view is not redrawn on every poll.
+	tailBufferLimit = 5000
+	tailTrimChunk   = 500
+)
+
+// tailSession is a live tail of a group or a single stream. start, newest,
+// seen and filter.Start/NextToken belong to the poll loop; everything else is
+// only touched on the UI goroutine.
+type tailSession struct {
+	filter awslogs.EventFilter
+	stream string
+	// start is where the tail began; polls never reach back before it.
+	start time.Time
+	// newest is the newest event timestamp seen, in milliseconds.
+	newest int64
+	// seen maps the IDs of the events inside the overlap window to their
+	// timestamps, as the next poll returns them again.
+	seen map[string]int64
+
+	events []types.FilteredLogEvent
+	// pending holds events received while paused.
+	pending   []types.FilteredLogEvent
+	paused    bool
+	failed    bool
+	highlight *regexp.Regexp
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newTailSession(group, stream, pattern string, start time.Time) *tailSession {
+	t := &tailSession{
+		filter: awslogs.EventFilter{
+			GroupName: group,
+			Pattern:   pattern,
+			Start:     start,
+			Limit:     tailPollLimit,
+		},
+		stream:    stream,
+		start:     start,
+		seen:      map[string]int64{},
+		highlight: patternHighlight(pattern),
+	}
+	if stream != "" {
+		t.filter.StreamNames = []string{stream}
+	}
+	return t
+}
+
+func (t *tailSession) label() string {
+	if t.stream == "" {
+		return t.filter.GroupName
+	}
+	return fmt.Sprintf("%s › %s", t.filter.GroupName, t.stream)
+}
+
+// poll fetches the events written since the previous poll. A poll that hit
+// tailPollLimit is resumed with its NextToken; otherwise the search starts
+// over tailOverlap before the newest event seen, and events already shown
+// are skipped by ID.
+func (t *tailSession) poll() ([]types.FilteredLogEvent, error) {
+	if t.filter.NextToken == nil {
+		start := t.start
+		if t.newest > 0 {
+			if overlap := time.UnixMilli(t.newest).Add(-tailOverlap); overlap.After(start) {
+				start = overlap
+			}
+		}
+		t.filter.Start = start
+		// IDs before the window are never returned again.
+		cutoff := start.UnixMilli()
+		for id, ts := range t.seen {
+			if ts < cutoff {
+				delete(t.seen, id)
+			}
+		}
+	}
+
+	events, token, err := awslogs.FilterLogEvents(t.filter)
+	if err != nil {
+		return nil, err
+	}
+	t.filter.NextToken = token
+
+	fresh := make([]types.FilteredLogEvent, 0, len(events))
+	for _, event := range events {
+		id := valueOr(event.EventId)
+		if _, ok := t.seen[id]; ok {
+			continue
+		}
+		ts := millis(event.Timestamp)
+		t.seen[id] = ts
+		t.newest = max(t.newest, ts)
+		fresh = append(fresh, event)
+	}
+	return fresh, nil
+}
+
+// openTail starts tailing group, or only stream when it is set.
+func (s *Service) openTail(group, stream string) {
+	if group == "" {
+		return
+	}
+	start := time.Now().Add(-tailLookback)
+	if stream != "" {
+		// A quiet stream shows its last few minutes of activity instead of
+		// an empty view.
+		for _, candidate := range s.streams {
+			if valueOr(candidate.LogStreamName) != stream {
+				continue
+			}
+			if last := awslogs.Time(candidate.LastEventTimestamp); last != nil && last.Before(time.Now()) {
+				start = last.Add(-tailLookback)
+			}
+		}
+	}
+
+	s.tailReturn = s.current
+	s.startTail(newTailSession(group, stream, "", start))
+}
+
+func (s *Service) startTail(t *tailSession) {
+	s.stopPolling(s.tail)
+	s.tail = t
+	s.ctx.SetError(nil)
+	s.tailView.Clear()
+	s.tailView.ScrollToEnd()
+	s.updateTailTitle()
+	s.showTailTab()
+	s.ctx.SetStatus(fmt.Sprintf("Tailing %s...", t.label()))
+	s.startPolling(t)
+}
+
+// restartTail starts the current tail over from the lookback window.
+func (s *Service) restartTail() {
+	if s.tail == nil {
+		return
+	}
+	s.startTail(newTailSession(s.tail.filter.GroupName, s.tail.stream, s.tail.filter.Pattern, time.Now().Add(-tailLookback)))
+}
+
+// setTailPattern restarts the tail with a new filter pattern, so the buffer
+// only holds matching events.
+func (s *Service) setTailPattern(pattern string) {
+	if s.tail == nil || pattern == s.tail.filter.Pattern {
+		return
+	}
+	t := newTailSession(s.tail.filter.GroupName, s.tail.stream, pattern, time.Now().Add(-tailLookback))
+	t.paused = s.tail.paused
+	s.startTail(t)
+}
+
+func (s *Service) closeTail() {
+	s.stopPolling(s.tail)
+	s.tail = nil
+	s.tailView.Clear()
+	if s.tailReturn == streamTab {
+		s.showStreamTab()
+		return
+	}
+	s.showGroupTab()
+}
+
+// startPolling runs the poll loop of t until stopPolling is called. A loop
+// started after a stop waits for the previous one to exit, so only one loop
+// ever advances the session's cursor.
+func (s *Service) startPolling(t *tailSession) {
+	if t == nil || t.stop != nil || s.ctx.App == nil {
+		return
+	}
+	previous := t.done
+	stop, done := make(chan struct{}), make(chan struct{})
+	t.stop, t.done = stop, done
+
+	go func() {
+		defer close(done)
+		if previous != nil {
+			<-previous
+		}
+		for {
+			events, err := t.poll()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			s.ctx.App.QueueUpdateDraw(func() {
+				s.receiveEvents(t, events, err)
+			})
+			select {
+			case <-stop:
+				return
+			case <-time.After(tailInterval):
+			}
+		}
+	}()
+}
+
+func (s *Service) stopPolling(t *tailSession) {
+	if t == nil || t.stop == nil {
+		return
+	}
+	close(t.stop)
+	t.stop = nil
+}
+
+func (s *Service) receiveEvents(t *tailSession, events []types.FilteredLogEvent, err error) {
+	if t != s.tail {
+		return
+	}
+	if err != nil {
+		t.failed = true
+		s.ctx.SetError(fmt.Errorf("filter log events: %w", err))
+		return
+	}
+	if t.failed {
+		t.failed = false
+		s.ctx.SetError(nil)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	if t.paused {
+		t.pending = trimEvents(append(t.pending, events...))
+		s.updateTailTitle()
+		return
+	}
+	s.appendTailEvents(events)
+}
+
+func (s *Service) appendTailEvents(events []types.FilteredLogEvent) {
+	t := s.tail
+	t.events = append(t.events, events...)
+	if len(t.events) > tailBufferLimit {
+		t.events = trimEvents(t.events)
+		s.renderTail()
+		return
+	}
+
+	var b strings.Builder
+	for _, event := range events {
+		s.writeEvent(&b, event)
+	}
+	fmt.Fprint(s.tailView, b.String())
+	s.updateTailTitle()
+}
+
+func (s *Service) renderTail() {
+	t := s.tail
+	if t == nil {
+		return
+	}
+	var b strings.Builder
+	for _, event := range t.events {
+		s.writeEvent(&b, event)
+	}
+	s.tailView.SetText(b.String())
+	s.updateTailTitle()
+}
+
+func (s *Service) writeEvent(b *strings.Builder, event types.FilteredLogEvent) {
+	fmt.Fprintf(b, "[gray]%s[-] ", formatEventTime(event.Timestamp))
+	if s.tail.stream == "" {
+		fmt.Fprintf(b, "[darkcyan]%s[-] ", tview.Escape(valueOr(event.LogStreamName)))
+	}
+	b.WriteString(highlight(s.formatMessage(valueOr(event.Message)), s.tail.highlight))
+	b.WriteString("\n")
+}
+
+func (s *Service) formatMessage(message string) string {
+	message = strings.TrimRight(message, "\r\n")
+	if !s.prettyJSON {
+		return message
+	}
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return message
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(trimmed), "", "  "); err != nil {
+		return message
+	}
+	return out.String()
+}
+
+func (s *Service) togglePause() {
+	t := s.tail
+	if t == nil {
+		return
+	}
+	t.paused = !t.paused
+	if t.paused {
+		s.updateTailTitle()
+		s.ctx.SetStatus("Tail paused; new events are held until it resumes")
+		return
+	}
+
+	pending := t.pending
+	t.pending = nil
+	if len(pending) > 0 {
+		s.appendTailEvents(pending)
+	}
+	s.updateTailTitle()
+	s.tailView.ScrollToEnd()
+	s.ctx.SetStatus(fmt.Sprintf("Tail resumed (%d new events)", len(pending)))
+}
+
+func (s *Service) togglePrettyJSON() {
+	s.prettyJSON = !s.prettyJSON
+	s.renderTail()
+	if s.prettyJSON {
+		s.ctx.SetStatus("Pretty-printing JSON messages")
+		return
+	}
+	s.ctx.SetStatus("Showing raw messages")
+}
+
+func (s *Service) updateTailTitle() {
+	t := s.tail
+	if t == nil {
+		return
+	}
+	state := "[green]live[-]"
+	if t.paused {
+		state = fmt.Sprintf("[yellow]paused, %d new[-]", len(t.pending))
+	}
+	title := fmt.Sprintf("Tail %s (%s, %d events)", tview.Escape(t.label()), state, len(t.events))
+	if t.filter.Pattern != "" {
+		title += fmt.Sprintf(" (pattern: %s)", tview.Escape(t.filter.Pattern))
+	}
+	s.tailView.SetTitle(title)
+}
+
+func (s *Service) openSaveForm() {
+	t := s.tail
+	if t == nil {
+		return
+	}
+	if len(t.events)+len(t.pending) == 0 {
+		s.ctx.SetStatus("Nothing to save yet")
+		return
+	}
+
+	pathInput := tview.NewInputField().
+		SetLabel("Local path: ").
+		SetText(filepath.Join(saveDir(), saveFileName(t.label())))
+
+	form := tview.NewForm().
+		AddTextView("Events", fmt.Sprintf("%d from %s", len(t.events)+len(t.pending), t.label()), 0, 1, false, false).
+		AddFormItem(pathInput)
+
+	form.AddButton("Save", func() {
+		target := expandHome(strings.TrimSpace(pathInput.GetText()))
+		if target == "" {
+			s.ctx.SetError(fmt.Errorf("local path is required"))
+			return
+		}
+		s.closeModal()
+		s.saveBuffer(target)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Save tail buffer")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(saveModalPageName, centerPrimitive(form, 100, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+// saveBuffer writes the buffered events, including those held while paused,
+// as plain text.
+func (s *Service) saveBuffer(target string) {
+	t := s.tail
+	if t == nil {
+		return
+	}
+	events := append(append([]types.FilteredLogEvent(nil), t.events...), t.pending...)
+
+	var b strings.Builder
+	for _, event := range events {
+		b.WriteString(formatEventTime(event.Timestamp))
+		if t.stream == "" {
+			b.WriteString(" " + valueOr(event.LogStreamName))
+		}
+		b.WriteString(" " + s.formatMessage(valueOr(event.Message)) + "\n")
+	}
+
+	if err := os.WriteFile(target, []byte(b.String()), 0o644); err != nil {
+		s.ctx.SetError(fmt.Errorf("save tail buffer: %w", err))
+		return
+	}
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Saved %d events to %s", len(events), target))
+}
+
+// trimEvents drops the oldest events once the buffer limit is exceeded.
+func trimEvents(events []types.FilteredLogEvent) []types.FilteredLogEvent {
+	if len(events) <= tailBufferLimit {
+		return events
+	}
+	drop := len(events) - tailBufferLimit + tailTrimChunk
+	return append([]types.FilteredLogEvent(nil), events[drop:]...)
+}
+
+// patternHighlight builds a regexp matching the terms of a CloudWatch filter
+// pattern: plain and quoted terms, optional "?" terms and %regex% terms.
+// Excluded "-" terms are skipped, and for JSON and space-delimited patterns
+// only the quoted values are highlighted.
+func patternHighlight(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil
+	}
+
+	var alternatives []string
+	if strings.HasPrefix(pattern, "{") || strings.HasPrefix(pattern, "[") {
+		for _, match := range regexp.MustCompile(`"([^"]*)"`).FindAllStringSubmatch(pattern, -1) {
+			if value := strings.Trim(match[1], "*"); value != "" {
+				alternatives = append(alternatives, regexp.QuoteMeta(value))
+			}
+		}
+	} else {
+		for _, term := range splitTerms(pattern) {
+			term = strings.TrimPrefix(term, "?")
+			switch {
+			case term == "" || strings.HasPrefix(term, "-"):
+				continue
+			case len(term) > 2 && strings.HasPrefix(term, "%") && strings.HasSuffix(term, "%"):
+				if _, err := regexp.Compile(term[1 : len(term)-1]); err == nil {
+					alternatives = append(alternatives, term[1:len(term)-1])
+				}
+			default:
+				alternatives = append(alternatives, regexp.QuoteMeta(term))
+			}
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil
+	}
+	re, err := regexp.Compile(strings.Join(alternatives, "|"))
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// splitTerms splits a filter pattern on spaces, keeping double-quoted
+// phrases together.
+func splitTerms(pattern string) []string {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+	for _, r := range pattern {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms
+}
+
+// highlight escapes text for the tail view and marks the matches of re.
+func highlight(text string, re *regexp.Regexp) string {
+	if re == nil {
+		return tview.Escape(text)
+	}
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, match := range re.FindAllStringIndex(text, -1) {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(tview.Escape(text[last:match[0]]))
+		fmt.Fprintf(&b, "[black:yellow]%s[-:-]", tview.Escape(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(tview.Escape(text[last:]))
+	return b.String()
+}
+
+func formatEventTime(ts *int64) string {
+	t := awslogs.Time(ts)
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05.000")
+}
+
+func millis(ts *int64) int64 {
+	if ts == nil {
+		return 0
+	}
+	return *ts
+}
+
+// saveFileName turns a tail label into a file name such as
+// "aws-lambda-api-20240102-150405.log".
+func saveFileName(label string) string {
+	name := strings.Trim(regexp.MustCompile(`[^A-Za-z0-9._-]+`).ReplaceAllString(label, "-"), "-")
+	if name == "" {
+		name = "logs"
+	}
+	return fmt.Sprintf("%s-%s.log", name, time.Now().Format("20060102-150405"))
+}
+
+func saveDir() string {
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return "."
+}
+
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return p
+}
@@ -68,6 +68,13 @@ type Service struct {
 	currentZoneID   string
 	currentZoneName string
 
+	// pendingZone and pendingRecord are the hosted zone (ID or name) and
+	// record another service asked to show; they are resolved once the zones
+	// and records are loaded.
+	pendingZone   string
+	pendingRecord string
+	loadingZones  bool
+
 	mu     sync.Mutex
 	active bool
 
@@ -191,6 +198,11 @@ func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
 			s.openEditRecord()
 			return nil
 		}
+	case 'g', 'G':
+		if s.recTable.HasFocus() {
+			s.jumpToLoadBalancer()
+			return nil
+		}
 	}
 
 	return event
@@ -226,10 +238,14 @@ func (s *Service) loadHostedZones() {
 	s.ctx.SetStatus("Fetching hosted zones...")
 	s.ctx.SetError(nil)
 
+	s.loadingZones = true
+
 	go func() {
 		zones, err := awsr53.ListHostedZones()
 		s.ctx.App.QueueUpdateDraw(func() {
+			s.loadingZones = false
 			if err != nil {
+				s.pendingZone, s.pendingRecord = "", ""
 				s.ctx.SetError(fmt.Errorf("list hosted zones: %w", err))
 				return
 			}
@@ -240,6 +256,10 @@ func (s *Service) loadHostedZones() {
 			s.renderZones()
 			s.showZoneTab()
 			s.ctx.SetStatus(fmt.Sprintf("Loaded %d hosted zones", len(zones)))
+			if pending := s.pendingZone; pending != "" && !s.openPendingZone() {
+				s.pendingZone, s.pendingRecord = "", ""
+				s.ctx.SetError(fmt.Errorf("hosted zone %s not found", pending))
+			}
 		})
 	}()
 }
@@ -266,6 +286,9 @@ func (s *Service) loadRecords(zoneID string) {
 			s.renderRecords()
 			s.showRecordTab()
 			s.ctx.SetStatus(fmt.Sprintf("Loaded %d records", len(records)))
+			if s.pendingRecord != "" {
+				s.selectPendingRecord()
+			}
 		})
 	}()
 }
@@ -589,6 +612,104 @@ func (s *Service) deleteRecord(record types.ResourceRecordSet) {
 	}()
 }
 
+// Navigate opens the hosted zone args[0], given by ID or name, and selects
+// the record named args[1] when present. Relative record names are resolved
+// against the zone.
+func (s *Service) Navigate(args ...string) {
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return
+	}
+	s.closeModal()
+	s.pendingZone = strings.TrimSpace(args[0])
+	s.pendingRecord = ""
+	if len(args) > 1 {
+		s.pendingRecord = strings.TrimSpace(args[1])
+	}
+	if s.openPendingZone() || s.loadingZones {
+		return
+	}
+	s.loadHostedZones()
+}
+
+// openPendingZone loads the records of the zone requested by Navigate. It
+// reports false when the zone is not among the loaded ones.
+func (s *Service) openPendingZone() bool {
+	if s.pendingZone == "" {
+		return false
+	}
+	for _, zone := range s.zones {
+		if !zoneIs(zone, s.pendingZone) {
+			continue
+		}
+		s.pendingZone = ""
+		s.filteredZones = append(s.filteredZones[:0], s.zones...)
+		s.renderZones()
+		s.currentZoneID = aws.ToString(zone.Id)
+		s.currentZoneName = aws.ToString(zone.Name)
+		s.loadRecords(s.currentZoneID)
+		return true
+	}
+	return false
+}
+
+func (s *Service) selectPendingRecord() {
+	name := normalizeRecordName(s.pendingRecord)
+	s.pendingRecord = ""
+	relative := name + "." + normalizeRecordName(s.currentZoneName)
+	for row := 1; row < s.recTable.GetRowCount(); row++ {
+		idx, ok := s.recordRowMap[row]
+		if !ok {
+			continue
+		}
+		recordName := normalizeRecordName(aws.ToString(s.filteredRecords[idx].Name))
+		if recordName == name || recordName == relative {
+			s.recTable.Select(row, 0)
+			s.ctx.SetStatus(fmt.Sprintf("Selected %s", recordName))
+			return
+		}
+	}
+	s.ctx.SetError(fmt.Errorf("record %s not found in %s", name, trimDot(s.currentZoneName)))
+}
+
+// jumpToLoadBalancer opens the ELB view at the load balancer the selected
+// alias or CNAME record points to.
+func (s *Service) jumpToLoadBalancer() {
+	record, ok := s.selectedRecord()
+	if !ok {
+		s.ctx.SetStatus("Select a record first")
+		return
+	}
+	target, ok := loadBalancerTarget(record)
+	if !ok {
+		s.ctx.SetStatus("Only ELB alias and CNAME records link to a load balancer")
+		return
+	}
+	if s.ctx.Navigate != nil {
+		s.ctx.Navigate("elb", target)
+	}
+}
+
+// loadBalancerTarget returns the load balancer DNS name of an ELB alias record
+// or of a CNAME record pointing at an elb.amazonaws.com name.
+func loadBalancerTarget(record types.ResourceRecordSet) (string, bool) {
+	if record.AliasTarget != nil {
+		if !awsr53.IsELBAlias(record.AliasTarget.HostedZoneId) {
+			return "", false
+		}
+		return awsr53.NormalizeDNSName(aws.ToString(record.AliasTarget.DNSName)), true
+	}
+	if record.Type != types.RRTypeCname {
+		return "", false
+	}
+	for _, rr := range record.ResourceRecords {
+		value := awsr53.NormalizeDNSName(aws.ToString(rr.Value))
+		if strings.HasSuffix(value, ".elb.amazonaws.com") {
+			return value, true
+		}
+	}
+	return "", false
+}
+
 func (s *Service) showZoneTab() {
 	s.current = zoneTab
 	s.pages.SwitchToPage("zones")
@@ -617,6 +738,23 @@ func zoneMatches(zone types.HostedZone, query string) bool {
 	return strings.Contains(name, query) || strings.Contains(id, query)
 }
 
+// zoneIs reports whether key is the ID (with or without the /hostedzone/
+// prefix) or the name of the zone.
+func zoneIs(zone types.HostedZone, key string) bool {
+	id := strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/")
+	if strings.TrimPrefix(key, "/hostedzone/") == id {
+		return true
+	}
+	return normalizeRecordName(aws.ToString(zone.Name)) == normalizeRecordName(key)
+}
+
+// normalizeRecordName lowercases a DNS name, drops the trailing dot and
+// unescapes the wildcard label Route53 returns as \052.
+func normalizeRecordName(name string) string {
+	name = strings.ReplaceAll(name, `\052`, "*")
+	return strings.ToLower(trimDot(strings.TrimSpace(name)))
+}
+
 func recordMatches(record types.ResourceRecordSet, query string) bool {
 	if query == "" {
 		return true
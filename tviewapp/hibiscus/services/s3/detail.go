@@ -0,0 +1,376 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awss3 "github.com/jaehong21/hibiscus/internal/aws/s3"
+)
+
+func (s *Service) openBucketDetail() {
+	row, _ := s.bucketTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredBuckets) {
+		return
+	}
+	name := valueOr(s.filteredBuckets[row-1].Name)
+	if name == "" {
+		return
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching bucket configuration...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Bucket %s", name))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEsc {
+			s.closeModal()
+		}
+	})
+
+	s.showModal(bucketModalPageName, centerPrimitive(view, 120, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Fetching configuration of %s...", name))
+
+	go func() {
+		config, err := awss3.DescribeBucketConfig(&name)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("describe bucket %s: %w", name, err))
+				return
+			}
+			view.SetText(formatBucketConfig(name, config))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded configuration of %s", name))
+		})
+	}()
+}
+
+func formatBucketConfig(name string, config *awss3.BucketConfig) string {
+	var b strings.Builder
+
+	section(&b, "Bucket")
+	field(&b, "Name", name)
+	field(&b, "Region", config.Region)
+	access := config.Access()
+	fmt.Fprintf(&b, "  [lightcyan]%-14s[-] [%s]%s[-]\n", "Access", accessColor(access).Name(), access)
+
+	if writeSection(&b, config, awss3.SectionPolicy, config.Policy == "") {
+		if config.PolicyPublic {
+			b.WriteString("  [red]S3 evaluates this policy as public[-]\n")
+		}
+		var pretty bytes.Buffer
+		policy := config.Policy
+		if err := json.Indent(&pretty, []byte(policy), "  ", "  "); err == nil {
+			policy = pretty.String()
+		}
+		fmt.Fprintf(&b, "  %s\n", tview.Escape(policy))
+	}
+
+	if writeSection(&b, config, awss3.SectionPublicAccessBlock, config.PublicAccessBlock == nil) {
+		block := config.PublicAccessBlock
+		blockField(&b, "BlockPublicAcls", block.BlockPublicAcls)
+		blockField(&b, "IgnorePublicAcls", block.IgnorePublicAcls)
+		blockField(&b, "BlockPublicPolicy", block.BlockPublicPolicy)
+		blockField(&b, "RestrictPublicBuckets", block.RestrictPublicBuckets)
+	}
+
+	if writeSection(&b, config, awss3.SectionACL, config.Owner == nil && len(config.Grants) == 0) {
+		if config.Owner != nil {
+			field(&b, "Owner", ownerName(config.Owner))
+		}
+		for _, grant := range config.Grants {
+			line := fmt.Sprintf("  %-14s %s", grant.Permission, tview.Escape(granteeName(grant.Grantee)))
+			if awss3.IsPublicGrant(grant) {
+				line = fmt.Sprintf("[red]%s (public)[-]", line)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionEncryption, len(config.Encryption) == 0) {
+		for _, rule := range config.Encryption {
+			if rule.ApplyServerSideEncryptionByDefault != nil {
+				field(&b, "Algorithm", string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+				if key := valueOr(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID); key != "" {
+					field(&b, "KMS key", key)
+				}
+			}
+			field(&b, "Bucket key", enabled(rule.BucketKeyEnabled))
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionLifecycle, len(config.Lifecycle) == 0) {
+		for _, rule := range config.Lifecycle {
+			writeLifecycleRule(&b, rule)
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionReplication, config.Replication == nil) {
+		field(&b, "Role", valueOr(config.Replication.Role))
+		for _, rule := range config.Replication.Rules {
+			priority := ""
+			if rule.Priority != nil {
+				priority = fmt.Sprintf(" priority %d", *rule.Priority)
+			}
+			fmt.Fprintf(&b, "  [white]%s[-] %s%s\n", tview.Escape(valueOr(rule.ID)), rule.Status, priority)
+			if filter := replicationFilter(rule); filter != "" {
+				fmt.Fprintf(&b, "    filter       %s\n", tview.Escape(filter))
+			}
+			if rule.Destination != nil {
+				destination := valueOr(rule.Destination.Bucket)
+				if rule.Destination.StorageClass != "" {
+					destination += fmt.Sprintf(" (%s)", rule.Destination.StorageClass)
+				}
+				fmt.Fprintf(&b, "    destination  %s\n", tview.Escape(destination))
+			}
+			if rule.DeleteMarkerReplication != nil {
+				fmt.Fprintf(&b, "    delete markers %s\n", rule.DeleteMarkerReplication.Status)
+			}
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionCORS, len(config.CORS) == 0) {
+		for idx, rule := range config.CORS {
+			label := valueOr(rule.ID)
+			if label == "" {
+				label = fmt.Sprintf("Rule %d", idx+1)
+			}
+			fmt.Fprintf(&b, "  [white]%s[-]\n", tview.Escape(label))
+			fmt.Fprintf(&b, "    origins      %s\n", tview.Escape(strings.Join(rule.AllowedOrigins, ", ")))
+			fmt.Fprintf(&b, "    methods      %s\n", strings.Join(rule.AllowedMethods, ", "))
+			if len(rule.AllowedHeaders) > 0 {
+				fmt.Fprintf(&b, "    headers      %s\n", tview.Escape(strings.Join(rule.AllowedHeaders, ", ")))
+			}
+			if len(rule.ExposeHeaders) > 0 {
+				fmt.Fprintf(&b, "    expose       %s\n", tview.Escape(strings.Join(rule.ExposeHeaders, ", ")))
+			}
+			if rule.MaxAgeSeconds != nil {
+				fmt.Fprintf(&b, "    max age      %ds\n", *rule.MaxAgeSeconds)
+			}
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionWebsite, config.Website == nil) {
+		website := config.Website
+		if redirect := website.RedirectAllRequestsTo; redirect != nil {
+			target := valueOr(redirect.HostName)
+			if redirect.Protocol != "" {
+				target = fmt.Sprintf("%s://%s", redirect.Protocol, target)
+			}
+			field(&b, "Redirect all", target)
+		} else {
+			field(&b, "Index", website.IndexDocument)
+			field(&b, "Error", website.ErrorDocument)
+		}
+		if website.RoutingRules > 0 {
+			field(&b, "Routing rules", fmt.Sprintf("%d", website.RoutingRules))
+		}
+	}
+
+	if writeSection(&b, config, awss3.SectionObjectLock, config.ObjectLock == nil) {
+		lock := config.ObjectLock
+		field(&b, "Enabled", string(lock.ObjectLockEnabled))
+		if lock.Rule != nil && lock.Rule.DefaultRetention != nil {
+			retention := lock.Rule.DefaultRetention
+			period := "-"
+			switch {
+			case retention.Days != nil:
+				period = fmt.Sprintf("%d days", *retention.Days)
+			case retention.Years != nil:
+				period = fmt.Sprintf("%d years", *retention.Years)
+			}
+			field(&b, "Retention", fmt.Sprintf("%s, %s", retention.Mode, period))
+		}
+	}
+
+	return b.String()
+}
+
+// writeSection writes the section title followed by its error or a "not
+// configured" note, and reports whether the caller should write the body.
+func writeSection(b *strings.Builder, config *awss3.BucketConfig, title string, empty bool) bool {
+	section(b, title)
+	if err := config.Errors[title]; err != nil {
+		fmt.Fprintf(b, "  [red]%s[-]\n", tview.Escape(err.Error()))
+		return false
+	}
+	if empty {
+		b.WriteString("  [gray]Not configured[-]\n")
+		return false
+	}
+	return true
+}
+
+func writeLifecycleRule(b *strings.Builder, rule types.LifecycleRule) {
+	fmt.Fprintf(b, "  [white]%s[-] %s\n", tview.Escape(valueOr(rule.ID)), rule.Status)
+	if filter := lifecycleFilter(rule); filter != "" {
+		fmt.Fprintf(b, "    filter       %s\n", tview.Escape(filter))
+	}
+	if expiration := rule.Expiration; expiration != nil {
+		switch {
+		case expiration.Days != nil:
+			fmt.Fprintf(b, "    expire       after %d days\n", *expiration.Days)
+		case expiration.Date != nil:
+			fmt.Fprintf(b, "    expire       on %s\n", expiration.Date.Format("2006-01-02"))
+		case expiration.ExpiredObjectDeleteMarker != nil && *expiration.ExpiredObjectDeleteMarker:
+			b.WriteString("    expire       expired delete markers\n")
+		}
+	}
+	for _, transition := range rule.Transitions {
+		when := "-"
+		switch {
+		case transition.Days != nil:
+			when = fmt.Sprintf("after %d days", *transition.Days)
+		case transition.Date != nil:
+			when = "on " + transition.Date.Format("2006-01-02")
+		}
+		fmt.Fprintf(b, "    transition   %s %s\n", transition.StorageClass, when)
+	}
+	for _, transition := range rule.NoncurrentVersionTransitions {
+		if transition.NoncurrentDays != nil {
+			fmt.Fprintf(b, "    noncurrent   %s after %d days\n", transition.StorageClass, *transition.NoncurrentDays)
+		}
+	}
+	if expiration := rule.NoncurrentVersionExpiration; expiration != nil && expiration.NoncurrentDays != nil {
+		line := fmt.Sprintf("    noncurrent   expire after %d days", *expiration.NoncurrentDays)
+		if expiration.NewerNoncurrentVersions != nil {
+			line += fmt.Sprintf(", keeping %d newer", *expiration.NewerNoncurrentVersions)
+		}
+		b.WriteString(line + "\n")
+	}
+	if abort := rule.AbortIncompleteMultipartUpload; abort != nil && abort.DaysAfterInitiation != nil {
+		fmt.Fprintf(b, "    multipart    abort incomplete after %d days\n", *abort.DaysAfterInitiation)
+	}
+}
+
+func lifecycleFilter(rule types.LifecycleRule) string {
+	switch filter := rule.Filter.(type) {
+	case *types.LifecycleRuleFilterMemberPrefix:
+		return prefixFilter(filter.Value)
+	case *types.LifecycleRuleFilterMemberTag:
+		return tagFilter(filter.Value)
+	case *types.LifecycleRuleFilterMemberObjectSizeGreaterThan:
+		return fmt.Sprintf("size > %d", filter.Value)
+	case *types.LifecycleRuleFilterMemberObjectSizeLessThan:
+		return fmt.Sprintf("size < %d", filter.Value)
+	case *types.LifecycleRuleFilterMemberAnd:
+		var parts []string
+		if filter.Value.Prefix != nil {
+			parts = append(parts, prefixFilter(*filter.Value.Prefix))
+		}
+		for _, tag := range filter.Value.Tags {
+			parts = append(parts, tagFilter(tag))
+		}
+		if filter.Value.ObjectSizeGreaterThan != nil {
+			parts = append(parts, fmt.Sprintf("size > %d", *filter.Value.ObjectSizeGreaterThan))
+		}
+		if filter.Value.ObjectSizeLessThan != nil {
+			parts = append(parts, fmt.Sprintf("size < %d", *filter.Value.ObjectSizeLessThan))
+		}
+		return strings.Join(parts, " and ")
+	}
+	// The deprecated rule-level prefix predates filters.
+	if rule.Prefix != nil {
+		return prefixFilter(*rule.Prefix)
+	}
+	return ""
+}
+
+func replicationFilter(rule types.ReplicationRule) string {
+	switch filter := rule.Filter.(type) {
+	case *types.ReplicationRuleFilterMemberPrefix:
+		return prefixFilter(filter.Value)
+	case *types.ReplicationRuleFilterMemberTag:
+		return tagFilter(filter.Value)
+	case *types.ReplicationRuleFilterMemberAnd:
+		var parts []string
+		if filter.Value.Prefix != nil {
+			parts = append(parts, prefixFilter(*filter.Value.Prefix))
+		}
+		for _, tag := range filter.Value.Tags {
+			parts = append(parts, tagFilter(tag))
+		}
+		return strings.Join(parts, " and ")
+	}
+	if rule.Prefix != nil {
+		return prefixFilter(*rule.Prefix)
+	}
+	return ""
+}
+
+func prefixFilter(prefix string) string {
+	if prefix == "" {
+		return "whole bucket"
+	}
+	return "prefix " + prefix
+}
+
+func tagFilter(tag types.Tag) string {
+	return fmt.Sprintf("tag %s=%s", valueOr(tag.Key), valueOr(tag.Value))
+}
+
+func blockField(b *strings.Builder, label string, value *bool) {
+	if value != nil && *value {
+		fmt.Fprintf(b, "  [lightcyan]%-22s[-] [green]on[-]\n", label)
+		return
+	}
+	fmt.Fprintf(b, "  [lightcyan]%-22s[-] [yellow]off[-]\n", label)
+}
+
+func ownerName(owner *types.Owner) string {
+	if name := valueOr(owner.DisplayName); name != "" {
+		return name
+	}
+	return valueOr(owner.ID)
+}
+
+func granteeName(grantee *types.Grantee) string {
+	if grantee == nil {
+		return "-"
+	}
+	switch {
+	case grantee.URI != nil:
+		return *grantee.URI
+	case grantee.DisplayName != nil:
+		return *grantee.DisplayName
+	case grantee.EmailAddress != nil:
+		return *grantee.EmailAddress
+	}
+	return valueOr(grantee.ID)
+}
+
+func enabled(value *bool) string {
+	if value != nil && *value {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func section(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "[yellow]%s[-]\n", title)
+}
+
+func field(b *strings.Builder, label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "  [lightcyan]%-14s[-] %s\n", label, tview.Escape(value))
+}
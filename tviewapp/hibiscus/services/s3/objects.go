@@ -0,0 +1,523 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awss3 "github.com/jaehong21/hibiscus/internal/aws/s3"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+// previewLimit caps how much of an object is fetched for preview.
+const previewLimit = 512 * 1024
+
+// progressInterval throttles transfer progress redraws.
+const progressInterval = 150 * time.Millisecond
+
+// presignExpiries are the offered lifetimes of a presigned URL; SigV4 allows
+// at most seven days.
+var presignExpiries = []struct {
+	label  string
+	expiry time.Duration
+}{
+	{"15 minutes", 15 * time.Minute},
+	{"1 hour", time.Hour},
+	{"12 hours", 12 * time.Hour},
+	{"1 day", 24 * time.Hour},
+	{"7 days", 7 * 24 * time.Hour},
+}
+
+// toggleMark marks or unmarks the selected entry for a multi-select delete and
+// moves the cursor down.
+func (s *Service) toggleMark() {
+	entry, ok := s.selectedEntry()
+	if !ok {
+		return
+	}
+	if s.showVersions && entry.isFolder() {
+		return
+	}
+	id := entry.id()
+	if s.marked[id] {
+		delete(s.marked, id)
+	} else {
+		s.marked[id] = true
+	}
+	row, _ := s.objectTable.GetSelection()
+	s.renderObjects()
+	s.objectTable.Select(min(row+1, len(s.filteredEntries)), 0)
+	if s.showVersions {
+		s.ctx.SetStatus(fmt.Sprintf("%d versions marked – c compares them", len(s.marked)))
+		return
+	}
+	s.ctx.SetStatus(fmt.Sprintf("%d marked – Ctrl+D deletes them", len(s.marked)))
+}
+
+func (s *Service) openPreview() {
+	entry, ok := s.selectedEntry()
+	if !ok || entry.isFolder() {
+		return
+	}
+	if entry.version != nil && entry.version.IsDeleteMarker {
+		s.ctx.SetStatus("Delete markers have no content")
+		return
+	}
+	bucket, key := s.currentBucket, entry.key()
+	var versionID *string
+	title := fmt.Sprintf("s3://%s/%s", bucket, key)
+	if entry.version != nil {
+		versionID = &entry.version.VersionID
+		title += fmt.Sprintf(" (version %s)", entry.version.VersionID)
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText("[yellow]Fetching object...[-]")
+	view.SetBorder(true)
+	view.SetTitle(title)
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(tcell.Key) {
+		s.closeModal()
+	})
+
+	s.showModal(previewModalPageName, centerPrimitive(view, 140, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+	s.ctx.SetError(nil)
+
+	go func() {
+		preview, err := awss3.GetObjectPreview(&bucket, &key, versionID, previewLimit)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("get object: %w", err))
+				return
+			}
+			view.SetText(formatPreview(key, preview))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Previewing %s", key))
+		})
+	}()
+}
+
+// formatPreview renders text objects as-is and JSON pretty-printed. Binary
+// content is not shown.
+func formatPreview(key string, preview *awss3.ObjectPreview) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[gray]%s", utils.GetSizeFromByte(&preview.Size))
+	if preview.ContentType != "" {
+		fmt.Fprintf(&b, " · %s", tview.Escape(preview.ContentType))
+	}
+	if preview.Truncated {
+		shown := int64(len(preview.Body))
+		fmt.Fprintf(&b, " · showing the first %s", utils.GetSizeFromByte(&shown))
+	}
+	b.WriteString("[-]\n\n")
+
+	body := preview.Body
+	if len(body) == 0 {
+		b.WriteString("[gray]Empty object[-]")
+		return b.String()
+	}
+	if isBinary(preview) {
+		b.WriteString("[gray]Binary object – download it with d to inspect[-]")
+		return b.String()
+	}
+
+	if !preview.Truncated && isJSON(key, preview.ContentType, body) {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err == nil {
+			body = pretty.Bytes()
+		}
+	}
+	b.WriteString(tview.Escape(string(body)))
+	return b.String()
+}
+
+// isBinary reports whether a preview holds content that is not UTF-8 text.
+func isBinary(preview *awss3.ObjectPreview) bool {
+	return bytes.IndexByte(preview.Body, 0) >= 0 || !utf8.Valid(trimPartialRune(preview.Body, preview.Truncated))
+}
+
+func isJSON(key, contentType string, body []byte) bool {
+	if strings.Contains(contentType, "json") || strings.HasSuffix(strings.ToLower(key), ".json") {
+		return true
+	}
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// trimPartialRune drops a multi-byte character cut in half by the preview
+// limit so the UTF-8 check does not mistake text for binary.
+func trimPartialRune(body []byte, truncated bool) []byte {
+	if !truncated {
+		return body
+	}
+	for cut := 0; cut < utf8.UTFMax && cut < len(body); cut++ {
+		if utf8.Valid(body[:len(body)-cut]) {
+			return body[:len(body)-cut]
+		}
+	}
+	return body
+}
+
+func (s *Service) openDownloadForm() {
+	entry, ok := s.selectedEntry()
+	if !ok || entry.isFolder() {
+		s.ctx.SetStatus("Select an object to download")
+		return
+	}
+	bucket, key := s.currentBucket, entry.key()
+
+	pathInput := tview.NewInputField().
+		SetLabel("Local path: ").
+		SetText(filepath.Join(downloadDir(), path.Base(key)))
+
+	form := tview.NewForm().
+		AddTextView("Object", fmt.Sprintf("s3://%s/%s", bucket, key), 0, 1, false, false).
+		AddFormItem(pathInput)
+
+	form.AddButton("Download", func() {
+		target := expandHome(strings.TrimSpace(pathInput.GetText()))
+		if target == "" {
+			s.ctx.SetError(fmt.Errorf("local path is required"))
+			return
+		}
+		s.closeModal()
+		s.download(bucket, key, target)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Download object")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(transferModalPageName, centerPrimitive(form, 100, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) download(bucket, key, target string) {
+	s.ctx.SetError(nil)
+	label := fmt.Sprintf("Downloading %s", path.Base(key))
+	s.ctx.SetStatus(label + "...")
+
+	go func() {
+		err := awss3.DownloadObject(&bucket, &key, target, s.transferProgress(label))
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("download %s: %w", key, err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Downloaded %s to %s", key, target))
+		})
+	}()
+}
+
+func (s *Service) openUploadForm() {
+	if s.currentBucket == "" {
+		return
+	}
+	bucket, prefix := s.currentBucket, s.currentPrefix
+
+	fileInput := tview.NewInputField().
+		SetLabel("Local file: ")
+	keyInput := tview.NewInputField().
+		SetLabel("Key: ").
+		SetText(prefix).
+		SetPlaceholder("Ends with / to keep the file name")
+
+	form := tview.NewForm().
+		AddTextView("Bucket", fmt.Sprintf("s3://%s", bucket), 0, 1, false, false).
+		AddFormItem(fileInput).
+		AddFormItem(keyInput)
+
+	form.AddButton("Upload", func() {
+		source := expandHome(strings.TrimSpace(fileInput.GetText()))
+		if source == "" {
+			s.ctx.SetError(fmt.Errorf("local file is required"))
+			return
+		}
+		key := strings.TrimSpace(keyInput.GetText())
+		if key == "" || strings.HasSuffix(key, awss3.Delimiter) {
+			key += filepath.Base(source)
+		}
+		s.closeModal()
+		s.upload(bucket, key, source)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Upload file")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(transferModalPageName, centerPrimitive(form, 100, 11))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) upload(bucket, key, source string) {
+	s.ctx.SetError(nil)
+	label := fmt.Sprintf("Uploading %s", filepath.Base(source))
+	s.ctx.SetStatus(label + "...")
+
+	go func() {
+		err := awss3.UploadFile(&bucket, key, source, s.transferProgress(label))
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("upload %s: %w", source, err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Uploaded %s to s3://%s/%s", source, bucket, key))
+			if bucket == s.currentBucket && s.current == objectTab {
+				s.selectKey = key
+				s.loadObjects(bucket, s.currentPrefix)
+			}
+		})
+	}()
+}
+
+// transferProgress returns a progress callback that renders a progress bar in
+// the status area at most every progressInterval.
+func (s *Service) transferProgress(label string) awss3.Progress {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return func(done, total int64) {
+		mu.Lock()
+		now := time.Now()
+		if done < total && now.Sub(last) < progressInterval {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.ctx.SetStatus(fmt.Sprintf("%s %s", label, progressBar(done, total)))
+		})
+	}
+}
+
+func progressBar(done, total int64) string {
+	const width = 24
+	if total <= 0 {
+		return utils.GetSizeFromByte(&done)
+	}
+	filled := int(float64(done) / float64(total) * width)
+	filled = min(max(filled, 0), width)
+	return fmt.Sprintf("▕%s%s▏ %3.0f%% %s/%s",
+		strings.Repeat("█", filled), strings.Repeat("░", width-filled),
+		float64(done)/float64(total)*100, utils.GetSizeFromByte(&done), utils.GetSizeFromByte(&total))
+}
+
+func (s *Service) openPresignForm() {
+	entry, ok := s.selectedEntry()
+	if !ok || entry.isFolder() {
+		s.ctx.SetStatus("Select an object to share")
+		return
+	}
+	bucket, key := s.currentBucket, entry.key()
+
+	labels := make([]string, 0, len(presignExpiries))
+	for _, option := range presignExpiries {
+		labels = append(labels, option.label)
+	}
+	expiry := tview.NewDropDown().
+		SetLabel("Expires in: ").
+		SetOptions(labels, nil).
+		SetCurrentOption(1)
+
+	form := tview.NewForm().
+		AddTextView("Object", fmt.Sprintf("s3://%s/%s", bucket, key), 0, 1, false, false).
+		AddFormItem(expiry)
+
+	form.AddButton("Copy URL", func() {
+		idx, _ := expiry.GetCurrentOption()
+		if idx < 0 {
+			return
+		}
+		s.closeModal()
+		s.copyPresignedURL(bucket, key, presignExpiries[idx].expiry, presignExpiries[idx].label)
+	})
+	form.AddButton("Cancel", func() {
+		s.closeModal()
+	})
+
+	form.SetCancelFunc(s.closeModal)
+	form.SetTitle("Presigned GET URL")
+	form.SetBorder(true)
+	form.SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignRight)
+
+	s.showModal(presignModalPageName, centerPrimitive(form, 90, 9))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(form)
+	}
+}
+
+func (s *Service) copyPresignedURL(bucket, key string, expiry time.Duration, label string) {
+	s.ctx.SetError(nil)
+	go func() {
+		url, err := awss3.PresignGetObject(&bucket, &key, expiry)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("presign %s: %w", key, err))
+				return
+			}
+			if err := clipboard.WriteAll(url); err != nil {
+				s.ctx.SetError(fmt.Errorf("failed to copy presigned URL: %w", err))
+				return
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Presigned URL for %s (expires in %s) copied to clipboard", path.Base(key), label))
+		})
+	}()
+}
+
+// confirmDelete deletes the marked entries, or the selected one when nothing
+// is marked. Folders are expanded to every key below them before asking.
+func (s *Service) confirmDelete() {
+	var targets []objectEntry
+	for _, entry := range s.entries {
+		if s.marked[entry.id()] {
+			targets = append(targets, entry)
+		}
+	}
+	if len(targets) == 0 {
+		entry, ok := s.selectedEntry()
+		if !ok {
+			return
+		}
+		targets = append(targets, entry)
+	}
+
+	bucket := s.currentBucket
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus("Collecting keys to delete...")
+
+	go func() {
+		var keys []string
+		for _, entry := range targets {
+			if !entry.isFolder() {
+				keys = append(keys, entry.key())
+				continue
+			}
+			prefix := entry.prefix
+			below, err := awss3.ListKeys(&bucket, &prefix)
+			if err != nil {
+				s.ctx.App.QueueUpdateDraw(func() {
+					s.ctx.SetError(fmt.Errorf("list %s: %w", prefix, err))
+				})
+				return
+			}
+			keys = append(keys, below...)
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.showDeleteConfirmation(bucket, targets, keys)
+		})
+	}()
+}
+
+func (s *Service) showDeleteConfirmation(bucket string, targets []objectEntry, keys []string) {
+	if len(keys) == 0 {
+		s.ctx.SetStatus("Nothing to delete")
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Delete %d objects from s3://%s?\n\n", len(keys), bucket)
+	for idx, entry := range targets {
+		if idx == 5 {
+			fmt.Fprintf(&b, "… and %d more\n", len(targets)-idx)
+			break
+		}
+		b.WriteString(entry.key())
+		if entry.isFolder() {
+			b.WriteString(" (folder)")
+		}
+		b.WriteString("\n")
+	}
+
+	modal := tview.NewModal().
+		SetText(b.String()).
+		AddButtons([]string{"Cancel", "Delete"}).
+		SetDoneFunc(func(_ int, label string) {
+			s.closeModal()
+			if label == "Delete" {
+				s.deleteKeys(bucket, keys)
+			}
+		})
+
+	s.showModal(deleteModalPageName, centerPrimitive(modal, 80, 16))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(modal)
+	}
+}
+
+func (s *Service) deleteKeys(bucket string, keys []string) {
+	s.ctx.SetError(nil)
+	s.ctx.SetStatus(fmt.Sprintf("Deleting %d objects...", len(keys)))
+
+	go func() {
+		deleted, err := awss3.DeleteObjects(&bucket, keys)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("delete objects: %w", err))
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Deleted %d of %d objects", deleted, len(keys)))
+			clear(s.marked)
+			if bucket == s.currentBucket && s.current == objectTab {
+				s.loadObjects(bucket, s.currentPrefix)
+			}
+		})
+	}()
+}
+
+// downloadDir defaults downloads to ~/Downloads when it exists.
+func downloadDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, "Downloads")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return "."
+}
+
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return p
+}
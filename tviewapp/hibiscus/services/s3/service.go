@@ -0,0 +1,886 @@
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awss3 "github.com/jaehong21/hibiscus/internal/aws/s3"
+	"github.com/jaehong21/hibiscus/tviewapp/hibiscus"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+type tab int
+
+const (
+	bucketTab tab = iota
+	objectTab
+)
+
+const (
+	contentPageName       = "s3-content"
+	previewModalPageName  = "s3-preview-modal"
+	transferModalPageName = "s3-transfer-modal"
+	presignModalPageName  = "s3-presign-modal"
+	deleteModalPageName   = "s3-delete-modal"
+	versionModalPageName  = "s3-version-modal"
+	bucketModalPageName   = "s3-bucket-modal"
+)
+
+// objectEntry is one row of the objects table: a folder (common prefix), an
+// object directly below the current prefix, or in versions mode one version
+// or delete marker of such an object.
+type objectEntry struct {
+	prefix  string
+	object  *types.Object
+	version *awss3.ObjectVersion
+}
+
+func (e objectEntry) key() string {
+	switch {
+	case e.object != nil:
+		return valueOr(e.object.Key)
+	case e.version != nil:
+		return e.version.Key
+	}
+	return e.prefix
+}
+
+// id distinguishes the versions of a key.
+func (e objectEntry) id() string {
+	if e.version != nil {
+		return e.version.Key + "?versionId=" + e.version.VersionID
+	}
+	return e.key()
+}
+
+func (e objectEntry) isFolder() bool {
+	return e.object == nil && e.version == nil
+}
+
+// Service implements the hibiscus.Service interface for Amazon S3.
+type Service struct {
+	ctx hibiscus.ServiceContext
+
+	root        *tview.Pages
+	layout      *tview.Flex
+	pages       *tview.Pages
+	filter      *tview.InputField
+	bucketTable *tview.Table
+	objectTable *tview.Table
+
+	current tab
+
+	buckets         []types.Bucket
+	filteredBuckets []types.Bucket
+
+	summaries        map[string]awss3.BucketSummary
+	summariesLoading bool
+
+	currentBucket   string
+	currentPrefix   string
+	entries         []objectEntry
+	filteredEntries []objectEntry
+	objectQuery     string
+	// nextToken continues a prefix listing that stopped at the key cap, and
+	// nextMarker does the same in versions mode.
+	nextToken  *string
+	nextMarker *awss3.VersionMarker
+	// showVersions lists every version and delete marker instead of objects.
+	showVersions bool
+	// selectKey is the key to select after the objects table next reloads.
+	selectKey string
+	// marked holds the keys selected for a multi-select delete, or the
+	// versions selected for a diff in versions mode.
+	marked map[string]bool
+
+	mu     sync.Mutex
+	active bool
+
+	activeModal string
+}
+
+func New(ctx hibiscus.ServiceContext) hibiscus.Service {
+	svc := &Service{
+		ctx:       ctx,
+		current:   bucketTab,
+		summaries: map[string]awss3.BucketSummary{},
+		marked:    map[string]bool{},
+	}
+
+	svc.filter = tview.NewInputField().
+		SetLabel("Filter (/): ").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+
+	svc.bucketTable = buildTable("S3 buckets")
+	svc.objectTable = buildTable("Objects")
+
+	svc.pages = tview.NewPages()
+	svc.pages.AddPage("buckets", svc.bucketTable, true, true)
+	svc.pages.AddPage("objects", svc.objectTable, true, false)
+
+	svc.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(svc.filter, 1, 0, true).
+		AddItem(svc.pages, 0, 1, true)
+
+	svc.root = tview.NewPages()
+	svc.root.AddPage(contentPageName, svc.layout, true, true)
+
+	svc.filter.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			svc.applyFilter(strings.TrimSpace(svc.filter.GetText()))
+		case tcell.KeyEsc:
+			svc.exitFilterMode()
+		}
+	})
+
+	return svc
+}
+
+func (s *Service) Name() string  { return "s3" }
+func (s *Service) Title() string { return "Amazon S3 – buckets › prefixes › objects" }
+func (s *Service) Primitive() tview.Primitive {
+	return s.root
+}
+
+func (s *Service) Init() {
+	s.loadBuckets()
+}
+
+func (s *Service) Activate() {
+	s.active = true
+	s.focusCurrentTable()
+}
+
+func (s *Service) Deactivate() {
+	s.active = false
+}
+
+func (s *Service) Refresh() {
+	if s.current == objectTab && s.currentBucket != "" {
+		s.loadObjects(s.currentBucket, s.currentPrefix)
+		return
+	}
+	s.loadBuckets()
+}
+
+func (s *Service) EnterFilterMode() bool {
+	if !s.canFocus() || s.modalVisible() {
+		return false
+	}
+	s.ctx.App.SetFocus(s.filter)
+	return true
+}
+
+// InFilterMode also reports true while a modal is open so typing into its
+// text fields does not trigger global shortcuts.
+func (s *Service) InFilterMode() bool {
+	return s.filter.HasFocus() || s.modalVisible()
+}
+
+func (s *Service) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event == nil {
+		return nil
+	}
+
+	if s.modalVisible() {
+		if event.Key() == tcell.KeyEsc {
+			s.closeModal()
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		if s.filter.HasFocus() {
+			s.exitFilterMode()
+			return nil
+		}
+		if s.current == objectTab {
+			s.openParentPrefix()
+			return nil
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if s.current == objectTab {
+			s.openParentPrefix()
+			return nil
+		}
+	case tcell.KeyEnter:
+		if s.bucketTable.HasFocus() {
+			s.openSelectedBucket()
+			return nil
+		}
+		if s.objectTable.HasFocus() {
+			s.openSelectedEntry()
+			return nil
+		}
+	case tcell.KeyCtrlD:
+		if s.objectTable.HasFocus() && s.showVersions {
+			s.confirmRemoveDeleteMarker()
+			return nil
+		}
+		if s.objectTable.HasFocus() {
+			s.confirmDelete()
+			return nil
+		}
+	}
+
+	switch event.Rune() {
+	case 'd':
+		if s.bucketTable.HasFocus() {
+			s.openBucketDetail()
+			return nil
+		}
+	case 'm', 'M':
+		if s.current == objectTab && s.hasMore() {
+			s.loadMoreObjects()
+			return nil
+		}
+	case 'v':
+		if s.current == objectTab {
+			s.toggleVersions()
+			return nil
+		}
+	case ' ':
+		if s.objectTable.HasFocus() {
+			s.toggleMark()
+			return nil
+		}
+	}
+
+	if s.showVersions {
+		switch event.Rune() {
+		case 'o':
+			if s.objectTable.HasFocus() {
+				s.confirmRestoreVersion()
+				return nil
+			}
+		case 'c':
+			if s.objectTable.HasFocus() {
+				s.compareVersions()
+				return nil
+			}
+		}
+		return event
+	}
+
+	switch event.Rune() {
+	case 'd':
+		if s.objectTable.HasFocus() {
+			s.openDownloadForm()
+			return nil
+		}
+	case 'u':
+		if s.current == objectTab {
+			s.openUploadForm()
+			return nil
+		}
+	case 'p':
+		if s.objectTable.HasFocus() {
+			s.openPresignForm()
+			return nil
+		}
+	}
+
+	return event
+}
+
+func (s *Service) exitFilterMode() {
+	s.filter.SetText("")
+	if s.modalVisible() {
+		return
+	}
+	s.focusCurrentTable()
+}
+
+func (s *Service) openSelectedBucket() {
+	row, _ := s.bucketTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredBuckets) {
+		return
+	}
+	bucket := s.filteredBuckets[row-1]
+	if bucket.Name == nil {
+		return
+	}
+	s.currentBucket = *bucket.Name
+	s.loadObjects(s.currentBucket, "")
+}
+
+func (s *Service) selectedEntry() (objectEntry, bool) {
+	if s.current != objectTab {
+		return objectEntry{}, false
+	}
+	row, _ := s.objectTable.GetSelection()
+	if row <= 0 || row-1 >= len(s.filteredEntries) {
+		return objectEntry{}, false
+	}
+	return s.filteredEntries[row-1], true
+}
+
+func (s *Service) openSelectedEntry() {
+	entry, ok := s.selectedEntry()
+	if !ok {
+		return
+	}
+	if !entry.isFolder() {
+		s.openPreview()
+		return
+	}
+	s.loadObjects(s.currentBucket, entry.prefix)
+}
+
+// openParentPrefix moves one folder up, or back to the bucket list from the
+// bucket root.
+func (s *Service) openParentPrefix() {
+	if s.currentPrefix == "" {
+		s.showBucketTab()
+		return
+	}
+	child := s.currentPrefix
+	s.selectKey = child
+	s.loadObjects(s.currentBucket, parentPrefix(child))
+}
+
+func (s *Service) loadBuckets() {
+	s.ctx.SetStatus("Fetching buckets...")
+	s.ctx.SetError(nil)
+
+	go func() {
+		buckets, err := awss3.DescribeBuckets()
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("list buckets: %w", err))
+				return
+			}
+			s.mu.Lock()
+			s.buckets = buckets
+			s.filteredBuckets = append([]types.Bucket(nil), buckets...)
+			s.mu.Unlock()
+			s.renderBuckets()
+			s.showBucketTab()
+			s.ctx.SetStatus(fmt.Sprintf("Loaded %d buckets", len(buckets)))
+
+			names := make([]string, 0, len(buckets))
+			for _, bucket := range buckets {
+				if bucket.Name != nil {
+					names = append(names, *bucket.Name)
+				}
+			}
+			s.loadSummaries(names)
+		})
+	}()
+}
+
+// loadSummaries describes every bucket in the background and fills in the
+// region, versioning, encryption and access columns once all of them finish.
+func (s *Service) loadSummaries(names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	s.summariesLoading = true
+	s.mu.Unlock()
+
+	go func() {
+		var (
+			done   int
+			failed int
+		)
+		awss3.DescribeBucketSummaries(names, func(name string, summary awss3.BucketSummary, err error) {
+			s.mu.Lock()
+			done++
+			if err != nil {
+				failed++
+			} else {
+				s.summaries[name] = summary
+			}
+			progress := done
+			s.mu.Unlock()
+
+			s.ctx.App.QueueUpdateDraw(func() {
+				s.ctx.SetStatus(fmt.Sprintf("Describing buckets (%d/%d)...", progress, len(names)))
+			})
+		})
+
+		s.ctx.App.QueueUpdateDraw(func() {
+			s.mu.Lock()
+			s.summariesLoading = false
+			s.mu.Unlock()
+			s.renderBuckets()
+			if failed > 0 {
+				s.ctx.SetError(fmt.Errorf("bucket settings unavailable for %d buckets", failed))
+			}
+			s.ctx.SetStatus(fmt.Sprintf("Described %d buckets", len(names)-failed))
+		})
+	}()
+}
+
+func (s *Service) loadObjects(bucket, prefix string) {
+	if bucket == "" {
+		return
+	}
+	s.ctx.SetStatus(fmt.Sprintf("Listing s3://%s/%s...", bucket, prefix))
+	s.ctx.SetError(nil)
+
+	name, dir, versions := bucket, prefix, s.showVersions
+	go func() {
+		page, err := fetchListing(name, dir, versions, nil, nil)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.selectKey = ""
+				s.ctx.SetError(fmt.Errorf("list objects: %w", err))
+				return
+			}
+			if name != s.currentBucket || dir != s.currentPrefix {
+				clear(s.marked)
+			}
+			s.currentBucket = name
+			s.currentPrefix = dir
+			s.objectQuery = ""
+			s.entries = s.entries[:0]
+			s.appendListing(page)
+			s.renderObjects()
+			s.showObjectTab()
+			s.ctx.SetStatus(s.listingStatus())
+		})
+	}()
+}
+
+// loadMoreObjects continues a listing that stopped at the key cap.
+func (s *Service) loadMoreObjects() {
+	bucket, prefix, versions := s.currentBucket, s.currentPrefix, s.showVersions
+	token, marker := s.nextToken, s.nextMarker
+	s.ctx.SetStatus(fmt.Sprintf("Listing more of s3://%s/%s...", bucket, prefix))
+	s.ctx.SetError(nil)
+
+	go func() {
+		page, err := fetchListing(bucket, prefix, versions, token, marker)
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				s.ctx.SetError(fmt.Errorf("list objects: %w", err))
+				return
+			}
+			if bucket != s.currentBucket || prefix != s.currentPrefix || versions != s.showVersions {
+				return
+			}
+			row, _ := s.objectTable.GetSelection()
+			s.appendListing(page)
+			s.applyObjectQuery()
+			s.renderObjects()
+			s.objectTable.Select(max(row, 1), 0)
+			s.ctx.SetStatus(s.listingStatus())
+		})
+	}()
+}
+
+// listingPage is one page of either an object or a version listing.
+type listingPage struct {
+	entries    []objectEntry
+	nextToken  *string
+	nextMarker *awss3.VersionMarker
+}
+
+func fetchListing(bucket, prefix string, versions bool, token *string, marker *awss3.VersionMarker) (*listingPage, error) {
+	page := &listingPage{}
+	if versions {
+		listing, err := awss3.ListObjectVersions(&bucket, &prefix, marker)
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range listing.Prefixes {
+			page.entries = append(page.entries, objectEntry{prefix: prefix})
+		}
+		for idx := range listing.Versions {
+			page.entries = append(page.entries, objectEntry{version: &listing.Versions[idx]})
+		}
+		page.nextMarker = listing.NextMarker
+		return page, nil
+	}
+
+	listing, err := awss3.ListObjects(&bucket, &prefix, token)
+	if err != nil {
+		return nil, err
+	}
+	for _, prefix := range listing.Prefixes {
+		page.entries = append(page.entries, objectEntry{prefix: prefix})
+	}
+	for idx := range listing.Objects {
+		page.entries = append(page.entries, objectEntry{object: &listing.Objects[idx]})
+	}
+	page.nextToken = listing.NextToken
+	return page, nil
+}
+
+// appendListing adds a listing page to the entries, folders first, and
+// refreshes the filtered view. Versions of a key keep their newest-first
+// order.
+func (s *Service) appendListing(page *listingPage) {
+	for _, entry := range page.entries {
+		// The folder placeholder object some tools create is the prefix itself.
+		if !entry.isFolder() && entry.key() == s.currentPrefix {
+			continue
+		}
+		s.entries = append(s.entries, entry)
+	}
+	sort.SliceStable(s.entries, func(i, j int) bool {
+		if s.entries[i].isFolder() != s.entries[j].isFolder() {
+			return s.entries[i].isFolder()
+		}
+		return s.entries[i].key() < s.entries[j].key()
+	})
+	s.nextToken = page.nextToken
+	s.nextMarker = page.nextMarker
+	s.applyObjectQuery()
+}
+
+func (s *Service) hasMore() bool {
+	return s.nextToken != nil || s.nextMarker != nil
+}
+
+func (s *Service) applyObjectQuery() {
+	s.filteredEntries = s.filteredEntries[:0]
+	for _, entry := range s.entries {
+		if s.objectQuery == "" || strings.Contains(strings.ToLower(s.relativeKey(entry)), s.objectQuery) {
+			s.filteredEntries = append(s.filteredEntries, entry)
+		}
+	}
+}
+
+func (s *Service) listingStatus() string {
+	folders, objects := 0, 0
+	for _, entry := range s.entries {
+		if entry.isFolder() {
+			folders++
+		} else {
+			objects++
+		}
+	}
+	noun := "objects"
+	if s.showVersions {
+		noun = "versions"
+	}
+	status := fmt.Sprintf("Loaded %d folders and %d %s", folders, objects, noun)
+	if s.hasMore() {
+		status += " – press m to load more"
+	}
+	return status
+}
+
+func (s *Service) applyFilter(query string) {
+	s.ctx.SetError(nil)
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	switch s.current {
+	case objectTab:
+		s.objectQuery = query
+		s.applyObjectQuery()
+		s.renderObjects()
+	default:
+		s.filteredBuckets = s.filteredBuckets[:0]
+		for _, bucket := range s.buckets {
+			if query == "" || strings.Contains(strings.ToLower(valueOr(bucket.Name)), query) {
+				s.filteredBuckets = append(s.filteredBuckets, bucket)
+			}
+		}
+		s.renderBuckets()
+	}
+
+	s.filter.SetText("")
+	s.exitFilterMode()
+}
+
+func (s *Service) renderBuckets() {
+	table := s.bucketTable
+	table.Clear()
+
+	headers := []string{"Bucket", "Region", "Created", "Versioning", "Encryption", "Access"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredBuckets) == 0 {
+		table.SetCell(1, 0, tableCell("No buckets found").SetSelectable(false))
+		return
+	}
+
+	row, _ := table.GetSelection()
+	for idx, bucket := range s.filteredBuckets {
+		name := valueOr(bucket.Name)
+		summary := s.summaryCells(name)
+		table.SetCell(idx+1, 0, tableCell(name))
+		table.SetCell(idx+1, 1, tableCell(summary.Region))
+		table.SetCell(idx+1, 2, tableCell(formatTime(bucket.CreationDate)))
+		table.SetCell(idx+1, 3, tableCell(summary.Versioning))
+		table.SetCell(idx+1, 4, tableCell(summary.Encryption))
+		table.SetCell(idx+1, 5, tableCell(summary.Access).SetTextColor(accessColor(summary.Access)))
+	}
+
+	// Keep the cursor in place when summary columns are filled in later.
+	if row <= 0 || row > len(s.filteredBuckets) {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+// summaryCells returns the summary columns for a bucket row, with
+// placeholders while the bucket has not been described yet.
+func (s *Service) summaryCells(name string) awss3.BucketSummary {
+	s.mu.Lock()
+	summary, ok := s.summaries[name]
+	loading := s.summariesLoading
+	s.mu.Unlock()
+
+	if !ok {
+		placeholder := "-"
+		if loading {
+			placeholder = "…"
+		}
+		return awss3.BucketSummary{Region: placeholder, Versioning: placeholder, Encryption: placeholder, Access: placeholder}
+	}
+	return summary
+}
+
+func accessColor(access string) tcell.Color {
+	switch access {
+	case awss3.AccessPublic:
+		return tcell.ColorRed
+	case awss3.AccessNotBlocked:
+		return tcell.ColorYellow
+	case awss3.AccessBlocked:
+		return tcell.ColorGreen
+	}
+	return tcell.ColorGray
+}
+
+func (s *Service) renderObjects() {
+	table := s.objectTable
+	table.Clear()
+
+	title := fmt.Sprintf("s3://%s/%s", s.currentBucket, s.currentPrefix)
+	if s.showVersions {
+		title += " (versions)"
+	}
+	if s.objectQuery != "" {
+		title += fmt.Sprintf(" (filter: %s)", s.objectQuery)
+	}
+	if s.hasMore() {
+		title += " – more keys, press m"
+	}
+	if len(s.marked) > 0 {
+		title += fmt.Sprintf(" – %d marked", len(s.marked))
+	}
+	table.SetTitle(title)
+
+	if s.showVersions {
+		s.renderVersions()
+		return
+	}
+
+	headers := []string{"Key", "Size", "Storage class", "Last modified", "ETag"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredEntries) == 0 {
+		msg := "This prefix is empty"
+		if len(s.entries) > 0 {
+			msg = "No keys match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	for idx, entry := range s.filteredEntries {
+		name := s.relativeKey(entry)
+		if s.marked[entry.id()] {
+			name = "✓ " + name
+		}
+		if entry.isFolder() {
+			table.SetCell(idx+1, 0, tableCell(name).SetTextColor(tcell.ColorLightSkyBlue))
+			for col := 1; col < len(headers); col++ {
+				table.SetCell(idx+1, col, tableCell("-"))
+			}
+			continue
+		}
+		object := entry.object
+		size := "-"
+		if object.Size != nil {
+			size = utils.GetSizeFromByte(object.Size)
+		}
+		table.SetCell(idx+1, 0, tableCell(name))
+		table.SetCell(idx+1, 1, tableCell(size))
+		table.SetCell(idx+1, 2, tableCell(string(object.StorageClass)))
+		table.SetCell(idx+1, 3, tableCell(formatTime(object.LastModified)))
+		table.SetCell(idx+1, 4, tableCell(strings.Trim(valueOr(object.ETag), `"`)))
+	}
+
+	s.restoreSelection()
+}
+
+// restoreSelection selects the first row, or the row of selectKey after a
+// reload.
+func (s *Service) restoreSelection() {
+	s.objectTable.Select(1, 0)
+	if s.selectKey == "" {
+		return
+	}
+	for idx, entry := range s.filteredEntries {
+		if entry.key() == s.selectKey {
+			s.objectTable.Select(idx+1, 0)
+			break
+		}
+	}
+	s.selectKey = ""
+}
+
+// relativeKey is the entry key without the current prefix.
+func (s *Service) relativeKey(entry objectEntry) string {
+	return strings.TrimPrefix(entry.key(), s.currentPrefix)
+}
+
+func (s *Service) showBucketTab() {
+	s.current = bucketTab
+	s.pages.SwitchToPage("buckets")
+	s.bucketTable.SetTitle("S3 buckets")
+	if !s.modalVisible() {
+		s.setFocus(s.bucketTable)
+	}
+}
+
+func (s *Service) showObjectTab() {
+	s.current = objectTab
+	s.pages.SwitchToPage("objects")
+	if !s.modalVisible() {
+		s.setFocus(s.objectTable)
+	}
+}
+
+// Navigate opens bucket args[0] at the prefix or key formed by the remaining
+// arguments. A path not ending in "/" is treated as a key whose folder is
+// opened with the key selected.
+func (s *Service) Navigate(args ...string) {
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return
+	}
+	s.closeModal()
+	bucket := strings.TrimSpace(args[0])
+	path := strings.Join(args[1:], awss3.Delimiter)
+	prefix := path
+	if path != "" && !strings.HasSuffix(path, awss3.Delimiter) {
+		prefix = parentPrefix(path)
+		s.selectKey = path
+	}
+	s.loadObjects(bucket, prefix)
+}
+
+// parentPrefix returns the folder containing key, e.g. "a/b/" for "a/b/c"
+// and "a/" for "a/b/".
+func parentPrefix(key string) string {
+	trimmed := strings.TrimSuffix(key, awss3.Delimiter)
+	idx := strings.LastIndex(trimmed, awss3.Delimiter)
+	if idx < 0 {
+		return ""
+	}
+	return trimmed[:idx+1]
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func headerCell(title string) *tview.TableCell {
+	return tview.NewTableCell(title).
+		SetTextColor(tcell.ColorLightCyan).
+		SetSelectable(false).
+		SetAlign(tview.AlignLeft)
+}
+
+func tableCell(value string) *tview.TableCell {
+	return tview.NewTableCell(value).
+		SetExpansion(1)
+}
+
+func valueOr(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func buildTable(title string) *tview.Table {
+	tbl := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	tbl.SetBorder(true)
+	tbl.SetTitle(title)
+	tbl.SetBorderColor(tcell.ColorDimGray)
+	return tbl
+}
+
+func centerPrimitive(content tview.Primitive, width, height int) tview.Primitive {
+	grid := tview.NewGrid().
+		SetColumns(0, width, 0).
+		SetRows(0, height, 0).
+		AddItem(content, 1, 1, 1, 1, 0, 0, true)
+	grid.SetBackgroundColor(tcell.ColorBlack)
+	return grid
+}
+
+func (s *Service) canFocus() bool {
+	return s.ctx.App != nil && s.active
+}
+
+func (s *Service) setFocus(p tview.Primitive) {
+	if !s.canFocus() || p == nil {
+		return
+	}
+	s.ctx.App.SetFocus(p)
+}
+
+func (s *Service) focusCurrentTable() {
+	if s.modalVisible() {
+		return
+	}
+	switch s.current {
+	case objectTab:
+		s.setFocus(s.objectTable)
+	default:
+		s.setFocus(s.bucketTable)
+	}
+}
+
+func (s *Service) showModal(name string, content tview.Primitive) {
+	if s.root == nil || content == nil {
+		return
+	}
+	if s.modalVisible() {
+		s.root.RemovePage(s.activeModal)
+	}
+	s.root.AddPage(name, content, true, true)
+	s.activeModal = name
+}
+
+func (s *Service) closeModal() {
+	if !s.modalVisible() || s.root == nil {
+		return
+	}
+	s.root.RemovePage(s.activeModal)
+	s.activeModal = ""
+	s.focusCurrentTable()
+}
+
+func (s *Service) modalVisible() bool {
+	return s.activeModal != ""
+}
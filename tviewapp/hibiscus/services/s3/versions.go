@@ -0,0 +1,358 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	awss3 "github.com/jaehong21/hibiscus/internal/aws/s3"
+	"github.com/jaehong21/hibiscus/utils"
+)
+
+// diffContext is how many unchanged lines are kept around each change.
+const diffContext = 3
+
+// toggleVersions switches the objects table between the latest objects and
+// every version and delete marker of the keys in the current folder.
+func (s *Service) toggleVersions() {
+	if s.currentBucket == "" {
+		return
+	}
+	if entry, ok := s.selectedEntry(); ok {
+		s.selectKey = entry.key()
+	}
+	s.showVersions = !s.showVersions
+	clear(s.marked)
+	s.loadObjects(s.currentBucket, s.currentPrefix)
+}
+
+func (s *Service) renderVersions() {
+	table := s.objectTable
+
+	headers := []string{"Key", "Version ID", "Latest", "Size", "Storage class", "Last modified"}
+	for col, title := range headers {
+		table.SetCell(0, col, headerCell(title))
+	}
+
+	if len(s.filteredEntries) == 0 {
+		msg := "This prefix is empty"
+		if len(s.entries) > 0 {
+			msg = "No keys match this filter"
+		}
+		table.SetCell(1, 0, tableCell(msg).SetSelectable(false))
+		return
+	}
+
+	previousKey := ""
+	for idx, entry := range s.filteredEntries {
+		if entry.isFolder() {
+			table.SetCell(idx+1, 0, tableCell(s.relativeKey(entry)).SetTextColor(tcell.ColorLightSkyBlue))
+			for col := 1; col < len(headers); col++ {
+				table.SetCell(idx+1, col, tableCell("-"))
+			}
+			continue
+		}
+
+		version := entry.version
+		// The key is only written on the newest version of each key.
+		name := ""
+		if version.Key != previousKey {
+			name = s.relativeKey(entry)
+			previousKey = version.Key
+		}
+		if s.marked[entry.id()] {
+			name = "✓ " + name
+		}
+
+		latest := ""
+		if version.IsLatest {
+			latest = "latest"
+		}
+		size := "-"
+		if version.Size != nil {
+			size = utils.GetSizeFromByte(version.Size)
+		}
+
+		color := tcell.ColorWhite
+		if version.IsDeleteMarker {
+			color = tcell.ColorIndianRed
+			size = "delete marker"
+		}
+		table.SetCell(idx+1, 0, tableCell(name).SetTextColor(color))
+		table.SetCell(idx+1, 1, tableCell(version.VersionID).SetTextColor(color))
+		table.SetCell(idx+1, 2, tableCell(latest).SetTextColor(tcell.ColorGreen))
+		table.SetCell(idx+1, 3, tableCell(size).SetTextColor(color))
+		table.SetCell(idx+1, 4, tableCell(version.StorageClass))
+		table.SetCell(idx+1, 5, tableCell(formatTime(version.LastModified)))
+	}
+
+	s.restoreSelection()
+}
+
+func (s *Service) selectedVersion() (*awss3.ObjectVersion, bool) {
+	entry, ok := s.selectedEntry()
+	if !ok || entry.version == nil {
+		return nil, false
+	}
+	return entry.version, true
+}
+
+func (s *Service) confirmRestoreVersion() {
+	version, ok := s.selectedVersion()
+	if !ok {
+		return
+	}
+	switch {
+	case version.IsDeleteMarker:
+		s.ctx.SetStatus("Delete markers cannot be restored – remove them with Ctrl+D")
+		return
+	case version.IsLatest:
+		s.ctx.SetStatus(fmt.Sprintf("%s is already the latest version", version.VersionID))
+		return
+	}
+
+	bucket, target := s.currentBucket, *version
+	text := fmt.Sprintf("Restore version %s of\n%s\n(%s)?\n\nIt is copied over the latest version; every existing version is kept.",
+		target.VersionID, target.Key, formatTime(target.LastModified))
+	s.confirmVersionChange(text, "Restore", func() {
+		s.ctx.SetStatus(fmt.Sprintf("Restoring %s...", target.Key))
+		go func() {
+			err := awss3.RestoreVersion(&bucket, target)
+			s.finishVersionChange(bucket, target.Key, err, fmt.Sprintf("Restored %s to version %s", target.Key, target.VersionID))
+		}()
+	})
+}
+
+func (s *Service) confirmRemoveDeleteMarker() {
+	version, ok := s.selectedVersion()
+	if !ok {
+		return
+	}
+	if !version.IsDeleteMarker {
+		s.ctx.SetStatus("Only delete markers can be removed in versions mode")
+		return
+	}
+
+	bucket, target := s.currentBucket, *version
+	text := fmt.Sprintf("Remove the delete marker %s of\n%s?", target.VersionID, target.Key)
+	if target.IsLatest {
+		text += "\n\nThe previous version becomes the latest again."
+	}
+	s.confirmVersionChange(text, "Remove", func() {
+		s.ctx.SetStatus(fmt.Sprintf("Removing delete marker of %s...", target.Key))
+		go func() {
+			err := awss3.DeleteVersion(&bucket, target)
+			s.finishVersionChange(bucket, target.Key, err, fmt.Sprintf("Removed delete marker of %s", target.Key))
+		}()
+	})
+}
+
+func (s *Service) confirmVersionChange(text, action string, apply func()) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Cancel", action}).
+		SetDoneFunc(func(_ int, label string) {
+			s.closeModal()
+			if label == action {
+				s.ctx.SetError(nil)
+				apply()
+			}
+		})
+
+	s.showModal(versionModalPageName, centerPrimitive(modal, 80, 12))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(modal)
+	}
+}
+
+// finishVersionChange reports the outcome of a restore or delete-marker
+// removal and reloads the versions with the key selected.
+func (s *Service) finishVersionChange(bucket, key string, err error, done string) {
+	s.ctx.App.QueueUpdateDraw(func() {
+		if err != nil {
+			s.ctx.SetError(fmt.Errorf("%s: %w", key, err))
+			return
+		}
+		s.ctx.SetStatus(done)
+		if bucket == s.currentBucket && s.current == objectTab {
+			s.selectKey = key
+			s.loadObjects(bucket, s.currentPrefix)
+		}
+	})
+}
+
+// compareVersions diffs two versions: the two marked ones, the marked one and
+// the selected one, or the selected one and the version before it.
+func (s *Service) compareVersions() {
+	var marked []awss3.ObjectVersion
+	for _, entry := range s.entries {
+		if entry.version != nil && s.marked[entry.id()] {
+			marked = append(marked, *entry.version)
+		}
+	}
+	selected, ok := s.selectedVersion()
+
+	var pair []awss3.ObjectVersion
+	switch {
+	case len(marked) > 2:
+		s.ctx.SetStatus("Mark at most two versions to compare")
+		return
+	case len(marked) == 2:
+		pair = marked
+	case len(marked) == 1 && ok && selected.VersionID != marked[0].VersionID:
+		pair = []awss3.ObjectVersion{marked[0], *selected}
+	case len(marked) == 0 && ok:
+		previous, found := s.previousVersion(selected)
+		if !found {
+			s.ctx.SetStatus(fmt.Sprintf("%s has no earlier version to compare with", selected.Key))
+			return
+		}
+		pair = []awss3.ObjectVersion{previous, *selected}
+	default:
+		s.ctx.SetStatus("Select or mark a second version to compare")
+		return
+	}
+
+	for _, version := range pair {
+		if version.IsDeleteMarker {
+			s.ctx.SetStatus("Delete markers have no content to compare")
+			return
+		}
+	}
+	older, newer := pair[0], pair[1]
+	if older.LastModified != nil && newer.LastModified != nil && older.LastModified.After(*newer.LastModified) {
+		older, newer = newer, older
+	}
+	s.showVersionDiff(older, newer)
+}
+
+// previousVersion returns the newest version of the same key that is older
+// than version, skipping delete markers in between.
+func (s *Service) previousVersion(version *awss3.ObjectVersion) (awss3.ObjectVersion, bool) {
+	for idx, entry := range s.entries {
+		if entry.version == nil || entry.version.VersionID != version.VersionID || entry.key() != version.Key {
+			continue
+		}
+		for _, older := range s.entries[idx+1:] {
+			if older.version == nil || older.key() != version.Key {
+				break
+			}
+			if !older.version.IsDeleteMarker {
+				return *older.version, true
+			}
+		}
+		break
+	}
+	return awss3.ObjectVersion{}, false
+}
+
+func (s *Service) showVersionDiff(older, newer awss3.ObjectVersion) {
+	bucket := s.currentBucket
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false).
+		SetText("[yellow]Fetching versions...[-]")
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("diff %s", newer.Key))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetDoneFunc(func(tcell.Key) {
+		s.closeModal()
+	})
+
+	s.showModal(versionModalPageName, centerPrimitive(view, 140, 40))
+	if s.ctx.App != nil {
+		s.ctx.App.SetFocus(view)
+	}
+	s.ctx.SetError(nil)
+
+	go func() {
+		before, err := fetchVersionLines(bucket, older)
+		var after []string
+		if err == nil {
+			after, err = fetchVersionLines(bucket, newer)
+		}
+		s.ctx.App.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				s.ctx.SetError(fmt.Errorf("compare versions: %w", err))
+				return
+			}
+			view.SetText(formatVersionDiff(older, newer, utils.DiffLines(before, after)))
+			view.ScrollToBeginning()
+			s.ctx.SetStatus(fmt.Sprintf("Compared two versions of %s", newer.Key))
+		})
+	}()
+}
+
+// fetchVersionLines downloads a version for diffing. Only text objects that
+// fit within the preview limit can be compared.
+func fetchVersionLines(bucket string, version awss3.ObjectVersion) ([]string, error) {
+	preview, err := awss3.GetObjectPreview(&bucket, &version.Key, &version.VersionID, previewLimit)
+	if err != nil {
+		return nil, fmt.Errorf("version %s: %w", version.VersionID, err)
+	}
+	if preview.Truncated {
+		limit := int64(previewLimit)
+		return nil, fmt.Errorf("version %s is larger than %s and cannot be compared", version.VersionID, utils.GetSizeFromByte(&limit))
+	}
+	if isBinary(preview) {
+		return nil, fmt.Errorf("version %s is not a text object", version.VersionID)
+	}
+	if len(preview.Body) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(preview.Body), "\n"), "\n"), nil
+}
+
+// formatVersionDiff colours a DiffLines result and collapses unchanged runs
+// longer than the context kept around each change.
+func formatVersionDiff(older, newer awss3.ObjectVersion, diff []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[red]--- %s (%s)[-]\n", older.VersionID, formatTime(older.LastModified))
+	fmt.Fprintf(&b, "[green]+++ %s (%s)[-]\n\n", newer.VersionID, formatTime(newer.LastModified))
+
+	changed := make([]bool, len(diff))
+	identical := true
+	for idx, line := range diff {
+		if !strings.HasPrefix(line, "  ") {
+			changed[idx] = true
+			identical = false
+		}
+	}
+	if identical {
+		b.WriteString("[gray]The versions are identical[-]")
+		return b.String()
+	}
+
+	// A line is shown when a change is within diffContext lines of it.
+	visible := make([]bool, len(diff))
+	for idx := range diff {
+		if !changed[idx] {
+			continue
+		}
+		for near := max(0, idx-diffContext); near <= min(len(diff)-1, idx+diffContext); near++ {
+			visible[near] = true
+		}
+	}
+
+	hidden := 0
+	for idx, line := range diff {
+		if !visible[idx] {
+			hidden++
+			continue
+		}
+		if hidden > 0 {
+			fmt.Fprintf(&b, "[gray]… %d unchanged lines[-]\n", hidden)
+			hidden = 0
+		}
+		fmt.Fprintf(&b, "[%s]%s[-]\n", utils.DiffColor(line), tview.Escape(line))
+	}
+	if hidden > 0 {
+		fmt.Fprintf(&b, "[gray]… %d unchanged lines[-]\n", hidden)
+	}
+	return b.String()
+}
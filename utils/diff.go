@@ -0,0 +1,99 @@
+package utils
+
+import "strings"
+
+// maxDiffCells bounds the LCS table of DiffLines; larger inputs fall back to
+// showing the differing middle as removed and added wholesale.
+const maxDiffCells = 4_000_000
+
+// DiffLines compares two texts line by line and returns every line prefixed
+// with "  ", "- " or "+ ", using the longest common subsequence so moved or
+// inserted lines do not cascade into unrelated changes.
+func DiffLines(before, after []string) []string {
+	var diff []string
+
+	// Common leading and trailing lines are kept out of the LCS table.
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	for _, line := range before[:prefix] {
+		diff = append(diff, "  "+line)
+	}
+
+	a := before[prefix : len(before)-suffix]
+	b := after[prefix : len(after)-suffix]
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			diff = append(diff, "- "+line)
+		}
+		for _, line := range b {
+			diff = append(diff, "+ "+line)
+		}
+	} else {
+		diff = append(diff, diffLCS(a, b)...)
+	}
+
+	for _, line := range before[len(before)-suffix:] {
+		diff = append(diff, "  "+line)
+	}
+	return diff
+}
+
+func diffLCS(a, b []string) []string {
+	// lengths[i][j] is the LCS length of a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, "  "+a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, "+ "+b[j])
+	}
+	return diff
+}
+
+// DiffColor is the tview colour of a DiffLines line: green for additions, red
+// for removals and white for unchanged lines.
+func DiffColor(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+"):
+		return "green"
+	case strings.HasPrefix(line, "-"):
+		return "red"
+	}
+	return "white"
+}
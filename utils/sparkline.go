@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"math"
+	"strings"
+)
+
+var blocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// Sparkline draws one block character per value, scaled from zero to the
+// largest value. NaN values are drawn as spaces and zeros as the lowest
+// block.
+func Sparkline(values []float64) string {
+	peak := maxValue(values)
+
+	var b strings.Builder
+	for _, value := range values {
+		switch {
+		case math.IsNaN(value):
+			b.WriteRune(' ')
+		case peak <= 0 || value <= 0:
+			b.WriteRune(blocks[1])
+		default:
+			level := int(math.Round(value / peak * 7))
+			b.WriteRune(blocks[1+min(max(level, 0), 7)])
+		}
+	}
+	return b.String()
+}
+
+// BarChart draws values as vertical bars height rows tall, one column per
+// value, scaled from zero to the largest value. Rows are returned top
+// first; NaN values leave their column empty.
+func BarChart(values []float64, height int) []string {
+	peak := maxValue(values)
+	steps := height * 8
+
+	rows := make([]string, height)
+	for row := range rows {
+		// Rows are filled from the bottom, so the top row is the last band.
+		floor := (height - 1 - row) * 8
+		var b strings.Builder
+		for _, value := range values {
+			if math.IsNaN(value) || peak <= 0 || value <= 0 {
+				b.WriteRune(' ')
+				continue
+			}
+			level := int(math.Round(value / peak * float64(steps)))
+			b.WriteRune(blocks[min(max(level-floor, 0), 8)])
+		}
+		rows[row] = b.String()
+	}
+	return rows
+}
+
+func maxValue(values []float64) float64 {
+	peak := 0.0
+	for _, value := range values {
+		if !math.IsNaN(value) && value > peak {
+			peak = value
+		}
+	}
+	return peak
+}